@@ -42,6 +42,13 @@ func main() {
 
 	server, resp, err := client.Servers.Get(ctx, serverName, opts)
 	if err != nil {
+		// Demonstrate error classification
+		if mcp.IsNotFound(err) {
+			fmt.Printf("Server '%s' not found\n", serverName)
+			fmt.Println("\nTip: Use 'go run ../list/main.go' to see available servers")
+			os.Exit(1)
+		}
+
 		// Demonstrate error type checking
 		if rateLimitErr, ok := err.(*mcp.RateLimitError); ok {
 			fmt.Printf("Rate limit exceeded! Try again after %v\n", rateLimitErr.Rate.Reset)
@@ -51,11 +58,19 @@ func main() {
 		}
 
 		if errResp, ok := err.(*mcp.ErrorResponse); ok {
-			fmt.Printf("API error (%d): %s\n", errResp.Response.StatusCode, errResp.Message)
+			message := errResp.Message
+			if message == "" {
+				message = errResp.Detail
+			}
+			fmt.Printf("API error (%d): %s\n", errResp.Response.StatusCode, message)
 			if len(errResp.Errors) > 0 {
 				fmt.Println("Details:")
 				for _, e := range errResp.Errors {
-					fmt.Printf("  - %s: %s\n", e.Field, e.Message)
+					location := e.Location
+					if location == "" {
+						location = e.Field
+					}
+					fmt.Printf("  - %s: %s\n", location, e.Message)
 				}
 			}
 			os.Exit(1)
@@ -112,10 +127,10 @@ func main() {
 		}
 	}
 
-	// Note: Registry metadata (ServerID, PublishedAt, UpdatedAt, IsLatest, Status)
+	// Note: Registry metadata (PublishedAt, UpdatedAt, IsLatest, Status)
 	// has been moved from ServerJSON.Meta.Official to ServerResponse.Meta.Official in API v2.
 	// Since Get() returns unwrapped ServerJSON, this metadata is not directly accessible here.
-	// To access registry metadata, you would need to use List() which returns ServerResponse.
+	// To access registry metadata, use GetWithMeta() which returns the full ServerResponse.
 
 	// Show rate limit information
 	if resp.Rate.Limit > 0 {
@@ -0,0 +1,193 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// tokenExpiryLeeway is subtracted from a registry JWT's expiry so that a token
+// about to expire is not sent with a request.
+const tokenExpiryLeeway = 30 * time.Second
+
+// TokenResponse represents a registry JWT issued by one of the
+// authentication endpoints of the MCP Registry API.
+type TokenResponse struct {
+	// RegistryToken is the registry JWT to send as a bearer token.
+	RegistryToken string `json:"registry_token"`
+
+	// ExpiresAt is the Unix time, in seconds, at which the token expires.
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+// Expiry returns the time at which the token expires, or the zero time if
+// the registry did not report one.
+func (t *TokenResponse) Expiry() time.Time {
+	if t.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(t.ExpiresAt, 0)
+}
+
+// SignatureTokenExchangeRequest represents the body of the DNS and HTTP
+// authentication requests, in which the caller proves control of Domain by
+// signing Timestamp with the private key whose public key is published for
+// the domain.
+type SignatureTokenExchangeRequest struct {
+	// Domain is the domain whose namespace the token grants access to.
+	Domain string `json:"domain"`
+
+	// Timestamp is the current time in RFC3339 format. The registry rejects
+	// timestamps that are more than a few seconds off.
+	Timestamp string `json:"timestamp"`
+
+	// SignedTimestamp is the hex-encoded signature of Timestamp.
+	SignedTimestamp string `json:"signed_timestamp"`
+}
+
+// ExchangeGitHubAccessToken exchanges a GitHub OAuth access token for a
+// registry JWT granting access to the io.github.<user> namespaces the GitHub
+// user can publish to. On success, the token is cached on the Client and sent
+// with subsequent write requests.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/exchange-github-token
+func (s *AuthService) ExchangeGitHubAccessToken(ctx context.Context, githubToken string) (*TokenResponse, *Response, error) {
+	body := struct {
+		GitHubToken string `json:"github_token"`
+	}{githubToken}
+
+	return s.exchange(ctx, "v0.1/auth/github-at", body)
+}
+
+// ExchangeGitHubOIDC exchanges a GitHub Actions OIDC token for a registry JWT.
+// On success, the token is cached on the Client and sent with subsequent
+// write requests.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/exchange-github-oidc-token
+func (s *AuthService) ExchangeGitHubOIDC(ctx context.Context, oidcToken string) (*TokenResponse, *Response, error) {
+	body := struct {
+		OIDCToken string `json:"oidc_token"`
+	}{oidcToken}
+
+	return s.exchange(ctx, "v0.1/auth/github-oidc", body)
+}
+
+// ExchangeDNS exchanges a signed timestamp for a registry JWT, proving
+// control of a domain through the public key published in its DNS TXT
+// record. On success, the token is cached on the Client and sent with
+// subsequent write requests.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/exchange-dns-token
+func (s *AuthService) ExchangeDNS(ctx context.Context, body *SignatureTokenExchangeRequest) (*TokenResponse, *Response, error) {
+	if body == nil {
+		return nil, nil, errors.New("exchange request must not be nil")
+	}
+
+	return s.exchange(ctx, "v0.1/auth/dns", body)
+}
+
+// ExchangeHTTP exchanges a signed timestamp for a registry JWT, proving
+// control of a domain through the public key served at
+// https://<domain>/.well-known/mcp-registry-auth. On success, the token is
+// cached on the Client and sent with subsequent write requests.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/exchange-http-token
+func (s *AuthService) ExchangeHTTP(ctx context.Context, body *SignatureTokenExchangeRequest) (*TokenResponse, *Response, error) {
+	if body == nil {
+		return nil, nil, errors.New("exchange request must not be nil")
+	}
+
+	return s.exchange(ctx, "v0.1/auth/http", body)
+}
+
+// ExchangeAnonymous obtains a registry JWT without credentials. Only
+// registries configured for anonymous publishing, typically local development
+// instances, accept it. On success, the token is cached on the Client and
+// sent with subsequent write requests.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-anonymous-token
+func (s *AuthService) ExchangeAnonymous(ctx context.Context) (*TokenResponse, *Response, error) {
+	return s.exchange(ctx, "v0.1/auth/none", nil)
+}
+
+// Token returns the registry JWT currently cached on the Client, or nil if
+// there is none or it has expired.
+func (s *AuthService) Token() *TokenResponse {
+	s.client.tokenMu.Lock()
+	defer s.client.tokenMu.Unlock()
+
+	if !s.client.tokenValid() {
+		return nil
+	}
+
+	token := &TokenResponse{RegistryToken: s.client.token}
+	if !s.client.tokenExpiresAt.IsZero() {
+		token.ExpiresAt = s.client.tokenExpiresAt.Unix()
+	}
+	return token
+}
+
+// exchange posts body to the authentication endpoint at u and caches the
+// returned registry JWT on the Client.
+func (s *AuthService) exchange(ctx context.Context, u string, body any) (*TokenResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Credentials are exchanged on their own; never send a previous token.
+	req.Header.Del("Authorization")
+
+	var token *TokenResponse
+	resp, err := s.client.Do(ctx, req, &token)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if token == nil || token.RegistryToken == "" {
+		return nil, resp, errors.New("registry returned an empty token")
+	}
+
+	s.client.setAuthToken(token.RegistryToken, token.Expiry())
+
+	return token, resp, nil
+}
+
+// SetAuthToken sets the registry JWT sent as a bearer token with write
+// requests, such as ServersService.Publish. The token is assumed not to
+// expire. An empty token clears it.
+func (c *Client) SetAuthToken(token string) {
+	c.setAuthToken(token, time.Time{})
+}
+
+// setAuthToken caches a registry JWT that expires at expiresAt, or never if
+// expiresAt is zero.
+func (c *Client) setAuthToken(token string, expiresAt time.Time) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	c.token = token
+	c.tokenExpiresAt = expiresAt
+}
+
+// authToken returns the registry JWT to send with write requests, or "" if
+// there is none or it has expired.
+func (c *Client) authToken() string {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if !c.tokenValid() {
+		return ""
+	}
+	return c.token
+}
+
+// tokenValid reports whether the cached token is set and unexpired. The
+// caller must hold tokenMu.
+func (c *Client) tokenValid() bool {
+	if c.token == "" {
+		return false
+	}
+	return c.tokenExpiresAt.IsZero() || time.Now().Before(c.tokenExpiresAt.Add(-tokenExpiryLeeway))
+}
@@ -0,0 +1,217 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestAuthService_Exchange(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name     string
+		path     string
+		wantBody map[string]string
+		exchange func(ctx context.Context, s *AuthService) (*TokenResponse, *Response, error)
+	}{
+		{
+			name:     "github access token",
+			path:     "/v0.1/auth/github-at",
+			wantBody: map[string]string{"github_token": "gho_abc"},
+			exchange: func(ctx context.Context, s *AuthService) (*TokenResponse, *Response, error) {
+				return s.ExchangeGitHubAccessToken(ctx, "gho_abc")
+			},
+		},
+		{
+			name:     "github oidc",
+			path:     "/v0.1/auth/github-oidc",
+			wantBody: map[string]string{"oidc_token": "eyJoidc"},
+			exchange: func(ctx context.Context, s *AuthService) (*TokenResponse, *Response, error) {
+				return s.ExchangeGitHubOIDC(ctx, "eyJoidc")
+			},
+		},
+		{
+			name: "dns",
+			path: "/v0.1/auth/dns",
+			wantBody: map[string]string{
+				"domain":           "example.com",
+				"timestamp":        "2024-01-01T00:00:00Z",
+				"signed_timestamp": "abcdef",
+			},
+			exchange: func(ctx context.Context, s *AuthService) (*TokenResponse, *Response, error) {
+				return s.ExchangeDNS(ctx, &SignatureTokenExchangeRequest{
+					Domain:          "example.com",
+					Timestamp:       "2024-01-01T00:00:00Z",
+					SignedTimestamp: "abcdef",
+				})
+			},
+		},
+		{
+			name: "http",
+			path: "/v0.1/auth/http",
+			wantBody: map[string]string{
+				"domain":           "example.com",
+				"timestamp":        "2024-01-01T00:00:00Z",
+				"signed_timestamp": "abcdef",
+			},
+			exchange: func(ctx context.Context, s *AuthService) (*TokenResponse, *Response, error) {
+				return s.ExchangeHTTP(ctx, &SignatureTokenExchangeRequest{
+					Domain:          "example.com",
+					Timestamp:       "2024-01-01T00:00:00Z",
+					SignedTimestamp: "abcdef",
+				})
+			},
+		},
+		{
+			name:     "anonymous",
+			path:     "/v0.1/auth/none",
+			wantBody: nil,
+			exchange: func(ctx context.Context, s *AuthService) (*TokenResponse, *Response, error) {
+				return s.ExchangeAnonymous(ctx)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			// A previously cached token must not be sent to the auth endpoint.
+			client.SetAuthToken("stale-token")
+
+			mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "POST")
+
+				if got := r.Header.Get("Authorization"); got != "" {
+					t.Errorf("Authorization header = %q, want none", got)
+				}
+
+				var body map[string]string
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil && tt.wantBody != nil {
+					t.Fatalf("decoding request body: %v", err)
+				}
+				if !reflect.DeepEqual(body, tt.wantBody) {
+					t.Errorf("request body = %v, want %v", body, tt.wantBody)
+				}
+
+				fmt.Fprintf(w, `{"registry_token": "registry-jwt", "expires_at": %d}`, expiresAt)
+			})
+
+			token, _, err := tt.exchange(context.Background(), client.Auth)
+			if err != nil {
+				t.Fatalf("exchange returned error: %v", err)
+			}
+
+			want := &TokenResponse{RegistryToken: "registry-jwt", ExpiresAt: expiresAt}
+			if !reflect.DeepEqual(token, want) {
+				t.Errorf("exchange returned %+v, want %+v", token, want)
+			}
+			if got := client.Auth.Token(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Auth.Token() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestAuthService_TokenInjectedOnWrites(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/auth/github-at", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"registry_token": "registry-jwt", "expires_at": %d}`, time.Now().Add(time.Hour).Unix())
+	})
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("read request Authorization = %q, want none", got)
+		}
+		fmt.Fprint(w, `{"servers": [], "metadata": {}}`)
+	})
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer registry-jwt" {
+			t.Errorf("write request Authorization = %q, want %q", got, "Bearer registry-jwt")
+		}
+		fmt.Fprint(w, `{"server": {"name": "io.github.example/server", "version": "1.0.0"}}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Auth.ExchangeGitHubAccessToken(ctx, "gho_abc"); err != nil {
+		t.Fatalf("Auth.ExchangeGitHubAccessToken returned error: %v", err)
+	}
+	if _, _, err := client.Servers.List(ctx, nil); err != nil {
+		t.Fatalf("Servers.List returned error: %v", err)
+	}
+	if _, _, err := client.Servers.Publish(ctx, &registryv0.ServerJSON{Name: "io.github.example/server", Version: "1.0.0"}); err != nil {
+		t.Fatalf("Servers.Publish returned error: %v", err)
+	}
+}
+
+func TestAuthService_ExpiredToken(t *testing.T) {
+	client := NewClient(nil)
+
+	client.setAuthToken("expired", time.Now().Add(-time.Minute))
+	if got := client.Auth.Token(); got != nil {
+		t.Errorf("Auth.Token() with expired token = %+v, want nil", got)
+	}
+
+	// Tokens about to expire are treated as expired.
+	client.setAuthToken("expiring", time.Now().Add(tokenExpiryLeeway/2))
+	req, _ := client.NewRequest(http.MethodPost, "v0.1/publish", nil)
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization with expiring token = %q, want none", got)
+	}
+
+	client.SetAuthToken("static")
+	if got := client.Auth.Token(); got == nil || got.RegistryToken != "static" || got.ExpiresAt != 0 {
+		t.Errorf("Auth.Token() with static token = %+v, want static token without expiry", got)
+	}
+}
+
+func TestAuthService_ExchangeErrors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/auth/github-at", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"title": "Unauthorized", "status": 401, "detail": "Invalid GitHub token"}`)
+	})
+	mux.HandleFunc("/v0.1/auth/none", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	ctx := context.Background()
+
+	if _, _, err := client.Auth.ExchangeGitHubAccessToken(ctx, "bad"); !IsUnauthorized(err) {
+		t.Errorf("Auth.ExchangeGitHubAccessToken error = %v, want IsUnauthorized", err)
+	}
+	if _, _, err := client.Auth.ExchangeAnonymous(ctx); err == nil {
+		t.Error("Auth.ExchangeAnonymous with empty token expected error, got nil")
+	}
+	if _, _, err := client.Auth.ExchangeDNS(ctx, nil); err == nil {
+		t.Error("Auth.ExchangeDNS(nil) expected error, got nil")
+	}
+	if _, _, err := client.Auth.ExchangeHTTP(ctx, nil); err == nil {
+		t.Error("Auth.ExchangeHTTP(nil) expected error, got nil")
+	}
+	if got := client.Auth.Token(); got != nil {
+		t.Errorf("Auth.Token() after failed exchanges = %+v, want nil", got)
+	}
+}
+
+func TestTokenResponse_Expiry(t *testing.T) {
+	if got := (&TokenResponse{}).Expiry(); !got.IsZero() {
+		t.Errorf("Expiry() without expires_at = %v, want zero time", got)
+	}
+
+	want := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if got := (&TokenResponse{ExpiresAt: want.Unix()}).Expiry(); !got.Equal(want) {
+		t.Errorf("Expiry() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,443 @@
+package mcp
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores GET responses for a Client. Implementations must be safe for
+// concurrent use. Entries returned by Get must not be modified by the caller;
+// the Client stores a new entry with Set instead.
+//
+// Caching is best effort: implementations that fail to read or write an
+// entry should behave as if it were absent.
+type Cache interface {
+	// Get returns the entry stored under key, if any.
+	Get(key string) (*CacheEntry, bool)
+
+	// Set stores entry under key, replacing any existing entry.
+	Set(key string, entry *CacheEntry)
+
+	// Delete removes the entry stored under key, if any.
+	Delete(key string)
+}
+
+// CacheEntry is a response stored in a Cache.
+type CacheEntry struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"status_code"`
+
+	// Header holds the response headers, including the validators (ETag and
+	// Last-Modified) and the Cache-Control directives.
+	Header http.Header `json:"header"`
+
+	// Body is the raw response body.
+	Body []byte `json:"body"`
+
+	// StoredAt is the time the response was received or last revalidated.
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// fresh reports whether the entry may be served at now without revalidating
+// it with the API. Freshness is determined by the max-age Cache-Control
+// directive or, failing that, the Expires header.
+func (e *CacheEntry) fresh(now time.Time) bool {
+	directives := parseCacheControl(e.Header)
+	if _, ok := directives["no-cache"]; ok {
+		return false
+	}
+
+	age := now.Sub(e.StoredAt)
+	if v, err := strconv.Atoi(e.Header.Get("Age")); err == nil && v > 0 {
+		age += time.Duration(v) * time.Second
+	}
+
+	if v, ok := directives["max-age"]; ok {
+		seconds, err := strconv.Atoi(v)
+		return err == nil && age < time.Duration(seconds)*time.Second
+	}
+
+	if expires, err := http.ParseTime(e.Header.Get("Expires")); err == nil {
+		return now.Before(expires)
+	}
+
+	return false
+}
+
+// conditional returns a copy of req that asks the API to confirm the entry
+// is still valid using its ETag and Last-Modified validators.
+func (e *CacheEntry) conditional(req *http.Request) *http.Request {
+	req = req.Clone(req.Context())
+	if etag := e.Header.Get("ETag"); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified := e.Header.Get("Last-Modified"); lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+	return req
+}
+
+// revalidated returns a copy of the entry updated with the headers of a
+// 304 Not Modified response received at now.
+func (e *CacheEntry) revalidated(header http.Header, now time.Time) *CacheEntry {
+	updated := &CacheEntry{
+		StatusCode: e.StatusCode,
+		Header:     e.Header.Clone(),
+		Body:       e.Body,
+		StoredAt:   now,
+	}
+	for k, v := range header {
+		if k == "Content-Length" {
+			continue
+		}
+		updated.Header[k] = v
+	}
+	return updated
+}
+
+// response builds a Response serving the entry as the answer to req.
+func (e *CacheEntry) response(req *http.Request) *Response {
+	return &Response{
+		Response: &http.Response{
+			Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+			StatusCode:    e.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        e.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(e.Body)),
+			ContentLength: int64(len(e.Body)),
+			Request:       req,
+		},
+		FromCache: true,
+	}
+}
+
+// cachedDo sends an API request through the Client's Cache, if any.
+//
+// Fresh GET responses are served from the cache without contacting the API,
+// unless the request carries a Cache-Control: no-cache header. Stale ones are
+// revalidated with a conditional request, and a 304 Not Modified reply is
+// served from the cache. Successful requests with unsafe methods, such as
+// PUT, remove the cached responses they make stale; see invalidatedKeys.
+// Like retryDo, it returns the number of attempts made, which is zero for a
+// cache hit.
+func (c *Client) cachedDo(ctx context.Context, req *http.Request) (*Response, int, error) {
+	if c.Cache == nil {
+		return c.retryDo(ctx, req)
+	}
+
+	if req.Method != http.MethodGet {
+		response, attempts, err := c.retryDo(ctx, req)
+		if err == nil && unsafeMethod(req.Method) {
+			for _, key := range invalidatedKeys(req) {
+				c.Cache.Delete(key)
+			}
+		}
+		return response, attempts, err
+	}
+
+	key := cacheKey(req)
+	_, noCache := parseCacheControl(req.Header)["no-cache"]
+
+	entry, ok := c.Cache.Get(key)
+	if ok && !noCache && entry.fresh(time.Now()) {
+		c.log(ctx, slog.LevelDebug, "mcp: cache hit", slog.String("url", sanitizeURL(req.URL).String()))
+		return entry.response(req), 0, nil
+	}
+	if ok {
+		req = entry.conditional(req)
+	}
+
+	response, attempts, err := c.retryDo(ctx, req)
+	if err != nil {
+		return response, attempts, err
+	}
+
+	if ok && response.StatusCode == http.StatusNotModified {
+		response.Body.Close()
+
+		entry = entry.revalidated(response.Header, time.Now())
+		c.Cache.Set(key, entry)
+		c.log(ctx, slog.LevelDebug, "mcp: cache revalidated", slog.String("url", sanitizeURL(req.URL).String()))
+
+		cached := entry.response(req)
+		cached.Rate = response.Rate
+		cached.Endpoint = response.Endpoint
+		return cached, attempts, nil
+	}
+
+	if response.StatusCode != http.StatusOK || !storable(response.Header) {
+		return response, attempts, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return response, attempts, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.Cache.Set(key, &CacheEntry{
+		StatusCode: response.StatusCode,
+		Header:     response.Header.Clone(),
+		Body:       body,
+		StoredAt:   time.Now(),
+	})
+
+	return response, attempts, nil
+}
+
+// cacheKey returns the key a response to req is cached under.
+func cacheKey(req *http.Request) string {
+	return req.URL.String()
+}
+
+// invalidatedKeys returns the keys of the cached responses made stale by a
+// successful write request: the response for its URL without the query
+// string and, for a write to a server version, the list of versions and the
+// latest version of that server.
+func invalidatedKeys(req *http.Request) []string {
+	u := *req.URL
+	u.RawQuery = ""
+	u.Fragment = ""
+	key := u.String()
+	keys := []string{key}
+
+	// .../servers/{name}/versions/{version}
+	if i := strings.LastIndex(key, "/versions/"); i >= 0 && !strings.Contains(key[i+len("/versions/"):], "/") {
+		versions := key[:i+len("/versions")]
+		keys = append(keys, versions, versions+"/latest")
+	}
+	return keys
+}
+
+// invalidateServer removes the cached list of versions and latest version of
+// the server with the given name, which a newly published version makes
+// stale.
+func (c *Client) invalidateServer(name string) {
+	if c.Cache == nil {
+		return
+	}
+
+	u, err := c.BaseURL.Parse(fmt.Sprintf("v0.1/servers/%s/versions", url.PathEscape(name)))
+	if err != nil {
+		return
+	}
+	versions := u.String()
+	c.Cache.Delete(versions)
+	c.Cache.Delete(versions + "/latest")
+}
+
+// unsafeMethod reports whether requests with the given method may modify the
+// resources of the API.
+func unsafeMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isConditional reports whether req asks the API to reply with 304 Not
+// Modified if a cached response is still valid.
+func isConditional(req *http.Request) bool {
+	return req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != ""
+}
+
+// storable reports whether a successful response with the given headers may
+// be cached: it must not be marked no-store, and must either carry a
+// validator to revalidate it with or remain fresh for some time.
+func storable(header http.Header) bool {
+	directives := parseCacheControl(header)
+	if _, ok := directives["no-store"]; ok {
+		return false
+	}
+
+	if header.Get("ETag") != "" || header.Get("Last-Modified") != "" {
+		return true
+	}
+
+	if v, ok := directives["max-age"]; ok {
+		seconds, err := strconv.Atoi(v)
+		return err == nil && seconds > 0
+	}
+	return header.Get("Expires") != ""
+}
+
+// parseCacheControl parses the Cache-Control header into a map of lower-case
+// directive names to their, possibly empty, values.
+func parseCacheControl(header http.Header) map[string]string {
+	directives := make(map[string]string)
+	for _, v := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(v, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name == "" {
+				continue
+			}
+			directives[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return directives
+}
+
+// MemoryCache is an in-memory Cache holding a bounded number of entries,
+// evicting the least recently used entry when full. It is safe for
+// concurrent use.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List // most recently used at the front
+	items      map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache returns a MemoryCache holding up to maxEntries entries. A
+// maxEntries of zero or less means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry stored under key, marking it as recently used.
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	m.ll.MoveToFront(el)
+	return el.Value.(*memoryCacheItem).entry, true
+}
+
+// Set stores entry under key, evicting the least recently used entry if the
+// cache is full.
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		el.Value.(*memoryCacheItem).entry = entry
+		m.ll.MoveToFront(el)
+		return
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryCacheItem{key: key, entry: entry})
+
+	if m.maxEntries > 0 && m.ll.Len() > m.maxEntries {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Delete removes the entry stored under key, if any.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.ll.Remove(el)
+		delete(m.items, key)
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.ll.Len()
+}
+
+// DiskCache is a Cache storing each entry as a JSON file in a directory, so
+// that cached responses survive process restarts. It is safe for concurrent
+// use, including by several processes sharing the directory.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a DiskCache storing entries in dir, creating the
+// directory if it does not exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Get returns the entry stored under key. Entries that cannot be read or
+// decoded are treated as absent.
+func (d *DiskCache) Get(key string) (*CacheEntry, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Set stores entry under key. The entry is written to a temporary file and
+// renamed into place, so readers never observe a partial entry. Write
+// errors are ignored.
+func (d *DiskCache) Set(key string, entry *CacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	f, err := os.CreateTemp(d.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), d.path(key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// Delete removes the entry stored under key, if any.
+func (d *DiskCache) Delete(key string) {
+	os.Remove(d.path(key))
+}
+
+// path returns the file an entry is stored in, named after a hash of its
+// key so that arbitrary URLs map to valid file names.
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,549 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const cachedServerBody = `{"server": {"name": "test/server", "version": "1.0.0"}}`
+
+func TestClient_Cache_ETag(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+
+	var requests int
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, cachedServerBody)
+	})
+
+	ctx := context.Background()
+	for i, wantFromCache := range []bool{false, true, true} {
+		server, resp, err := client.Servers.Get(ctx, "test/server", nil)
+		if err != nil {
+			t.Fatalf("request %d: Servers.Get returned error: %v", i, err)
+		}
+		if resp.FromCache != wantFromCache {
+			t.Errorf("request %d: FromCache = %v, want %v", i, resp.FromCache, wantFromCache)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("request %d: StatusCode = %d, want %d", i, resp.StatusCode, http.StatusOK)
+		}
+		want := &registryv0.ServerJSON{Name: "test/server", Version: "1.0.0"}
+		if !reflect.DeepEqual(server, want) {
+			t.Errorf("request %d: Servers.Get returned %+v, want %+v", i, server, want)
+		}
+	}
+
+	// Each request is revalidated since the response carries no freshness
+	if requests != 3 {
+		t.Errorf("registry received %d requests, want 3", requests)
+	}
+}
+
+func TestClient_Cache_LastModified(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+
+	const lastModified = "Mon, 01 Jan 2024 00:00:00 GMT"
+	var gotIfModifiedSince string
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		gotIfModifiedSince = r.Header.Get("If-Modified-Since")
+		if gotIfModifiedSince == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		fmt.Fprint(w, cachedServerBody)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Servers.Get(ctx, "test/server", nil); err != nil {
+		t.Fatalf("Servers.Get returned error: %v", err)
+	}
+	_, resp, err := client.Servers.Get(ctx, "test/server", nil)
+	if err != nil {
+		t.Fatalf("Servers.Get returned error: %v", err)
+	}
+
+	if gotIfModifiedSince != lastModified {
+		t.Errorf("If-Modified-Since = %q, want %q", gotIfModifiedSince, lastModified)
+	}
+	if !resp.FromCache {
+		t.Error("FromCache = false, want true")
+	}
+}
+
+func TestClient_Cache_CacheControl(t *testing.T) {
+	tests := []struct {
+		name         string
+		header       http.Header
+		wantRequests int
+	}{
+		{
+			name:         "fresh response served without request",
+			header:       http.Header{"Cache-Control": {"max-age=60"}},
+			wantRequests: 1,
+		},
+		{
+			name:         "expires in the future",
+			header:       http.Header{"Expires": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}},
+			wantRequests: 1,
+		},
+		{
+			name:         "no-store",
+			header:       http.Header{"Cache-Control": {"no-store, max-age=60"}, "Etag": {`"v1"`}},
+			wantRequests: 2,
+		},
+		{
+			name:         "no-cache revalidates",
+			header:       http.Header{"Cache-Control": {"no-cache, max-age=60"}, "Etag": {`"v1"`}},
+			wantRequests: 2,
+		},
+		{
+			name:         "no validators or freshness",
+			header:       http.Header{},
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			client.Cache = NewMemoryCache(10)
+
+			var requests int
+			mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				fmt.Fprint(w, cachedServerBody)
+			})
+
+			ctx := context.Background()
+			for range 2 {
+				if _, _, err := client.Servers.Get(ctx, "test/server", nil); err != nil {
+					t.Fatalf("Servers.Get returned error: %v", err)
+				}
+			}
+
+			if requests != tt.wantRequests {
+				t.Errorf("registry received %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestClient_Cache_ErrorsNotCached(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404}`)
+	})
+
+	for range 2 {
+		_, resp, err := client.Servers.Get(context.Background(), "test/server", nil)
+		if !IsNotFound(err) {
+			t.Fatalf("Servers.Get error = %v, want IsNotFound", err)
+		}
+		if resp.FromCache {
+			t.Error("FromCache = true for an error response")
+		}
+	}
+}
+
+func TestClient_Cache_InvalidatedByWrite(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *ServerEditOptions
+	}{
+		{name: "edit", opts: nil},
+		{name: "edit with status", opts: &ServerEditOptions{Status: model.StatusDeprecated}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			client.Cache = NewMemoryCache(10)
+			client.SetAuthToken("token")
+
+			gets := make(map[string]int)
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					gets[r.URL.EscapedPath()]++
+					w.Header().Set("Cache-Control", "max-age=60")
+				}
+				if strings.HasSuffix(r.URL.Path, "/versions") {
+					fmt.Fprintf(w, `{"servers": [%s], "metadata": {}}`, cachedServerBody)
+					return
+				}
+				fmt.Fprint(w, cachedServerBody)
+			}
+			mux.HandleFunc("/v0.1/servers/test%2Fserver/versions", handler)
+			mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", handler)
+			mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/1.0.0", handler)
+
+			ctx := context.Background()
+			getAll := func() {
+				t.Helper()
+				if _, _, err := client.Servers.GetByNameExactVersion(ctx, "test/server", "1.0.0"); err != nil {
+					t.Fatalf("Servers.GetByNameExactVersion returned error: %v", err)
+				}
+				if _, _, err := client.Servers.Get(ctx, "test/server", nil); err != nil {
+					t.Fatalf("Servers.Get returned error: %v", err)
+				}
+				if _, _, err := client.Servers.ListVersionsByName(ctx, "test/server"); err != nil {
+					t.Fatalf("Servers.ListVersionsByName returned error: %v", err)
+				}
+			}
+
+			getAll()
+			getAll()
+			for path, n := range gets {
+				if n != 1 {
+					t.Fatalf("registry received %d GET requests for %s before edit, want 1", n, path)
+				}
+			}
+
+			server := &registryv0.ServerJSON{Name: "test/server", Version: "1.0.0"}
+			if _, _, err := client.Servers.Edit(ctx, "test/server", "1.0.0", server, tt.opts); err != nil {
+				t.Fatalf("Servers.Edit returned error: %v", err)
+			}
+
+			getAll()
+			if len(gets) != 3 {
+				t.Fatalf("registry received GET requests for %v, want 3 paths", gets)
+			}
+			for path, n := range gets {
+				if n != 2 {
+					t.Errorf("registry received %d GET requests for %s after edit, want 2", n, path)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_Cache_InvalidatedByPublish(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+	client.SetAuthToken("token")
+
+	gets := make(map[string]int)
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions", func(w http.ResponseWriter, r *http.Request) {
+		gets[r.URL.EscapedPath()]++
+		w.Header().Set("Cache-Control", "max-age=300")
+		fmt.Fprintf(w, `{"servers": [%s], "metadata": {}}`, cachedServerBody)
+	})
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		gets[r.URL.EscapedPath()]++
+		w.Header().Set("Cache-Control", "max-age=300")
+		fmt.Fprint(w, cachedServerBody)
+	})
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"server": {"name": "test/server", "version": "2.0.0"}}`)
+	})
+
+	ctx := context.Background()
+	getAll := func() {
+		t.Helper()
+		if _, _, err := client.Servers.Get(ctx, "test/server", nil); err != nil {
+			t.Fatalf("Servers.Get returned error: %v", err)
+		}
+		if _, _, err := client.Servers.ListVersionsByName(ctx, "test/server"); err != nil {
+			t.Fatalf("Servers.ListVersionsByName returned error: %v", err)
+		}
+	}
+
+	getAll()
+	getAll()
+
+	server := &registryv0.ServerJSON{Name: "test/server", Version: "2.0.0"}
+	if _, _, err := client.Servers.Publish(ctx, server); err != nil {
+		t.Fatalf("Servers.Publish returned error: %v", err)
+	}
+
+	getAll()
+	if len(gets) != 2 {
+		t.Fatalf("registry received GET requests for %v, want 2 paths", gets)
+	}
+	for path, n := range gets {
+		if n != 2 {
+			t.Errorf("registry received %d GET requests for %s, want 2", n, path)
+		}
+	}
+}
+
+func TestClient_Cache_UpdateStatus(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+	client.SetAuthToken("token")
+
+	status := model.StatusActive
+	description := "A server"
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var body registryv0.ServerJSON
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+			if body.Description != description {
+				t.Errorf("request body description = %q, want the current %q", body.Description, description)
+			}
+			status = model.Status(r.URL.Query().Get("status"))
+		}
+		w.Header().Set("Cache-Control", "max-age=300")
+		fmt.Fprintf(w, `{
+			"server": {"name": "test/server", "description": %q, "version": "1.0.0"},
+			"_meta": {"io.modelcontextprotocol.registry/official": {"status": %q}}
+		}`, description, status)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Servers.GetWithMeta(ctx, "test/server", &ServerGetOptions{Version: "1.0.0"}); err != nil {
+		t.Fatalf("Servers.GetWithMeta returned error: %v", err)
+	}
+
+	// An edit made elsewhere must not be overwritten from the cached copy
+	description = "Edited elsewhere"
+
+	updated, _, err := client.Servers.UpdateStatus(ctx, "test/server", "1.0.0", model.StatusDeprecated)
+	if err != nil {
+		t.Fatalf("Servers.UpdateStatus returned error: %v", err)
+	}
+	if got := updated.Meta.Official.Status; got != model.StatusDeprecated {
+		t.Errorf("Servers.UpdateStatus status = %s, want %s", got, model.StatusDeprecated)
+	}
+
+	got, resp, err := client.Servers.GetWithMeta(ctx, "test/server", &ServerGetOptions{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("Servers.GetWithMeta returned error: %v", err)
+	}
+	if got.Meta.Official.Status != model.StatusDeprecated || resp.FromCache {
+		t.Errorf("Servers.GetWithMeta after UpdateStatus = %s (FromCache %v), want %s from the registry",
+			got.Meta.Official.Status, resp.FromCache, model.StatusDeprecated)
+	}
+}
+
+func TestClient_Cache_SafeMethodsKeepEntry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	cache := NewMemoryCache(10)
+	client.Cache = cache
+
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	if _, _, err := client.Meta.Ping(context.Background()); err != nil {
+		t.Fatalf("Meta.Ping returned error: %v", err)
+	}
+
+	for _, method := range []string{http.MethodHead, http.MethodOptions} {
+		req, err := client.NewRequest(method, "v0.1/ping", nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+		if _, err := client.Do(context.Background(), req, nil); err != nil {
+			t.Fatalf("Do(%s) returned error: %v", method, err)
+		}
+		if cache.Len() != 1 {
+			t.Errorf("cache holds %d entries after %s, want 1", cache.Len(), method)
+		}
+	}
+}
+
+func TestClient_Cache_RequestNoCache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+
+	var requests int
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	for _, noCache := range []bool{false, true, false} {
+		req, err := client.NewRequest(http.MethodGet, "v0.1/ping", nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+		if noCache {
+			req.Header.Set("Cache-Control", "no-cache")
+		}
+		resp, err := client.Do(context.Background(), req, nil)
+		if err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+		if noCache && resp.FromCache {
+			t.Error("no-cache request served from the cache")
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("registry received %d requests, want 2", requests)
+	}
+}
+
+func TestCacheEntry_fresh(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		header   http.Header
+		storedAt time.Time
+		want     bool
+	}{
+		{"within max-age", http.Header{"Cache-Control": {"public, max-age=60"}}, now.Add(-30 * time.Second), true},
+		{"past max-age", http.Header{"Cache-Control": {"max-age=60"}}, now.Add(-90 * time.Second), false},
+		{"age header counts", http.Header{"Cache-Control": {"max-age=60"}, "Age": {"45"}}, now.Add(-30 * time.Second), false},
+		{"quoted max-age", http.Header{"Cache-Control": {`max-age="60"`}}, now, true},
+		{"invalid max-age", http.Header{"Cache-Control": {"max-age=soon"}}, now, false},
+		{"max-age overrides expires", http.Header{"Cache-Control": {"max-age=0"}, "Expires": {now.Add(time.Hour).Format(http.TimeFormat)}}, now, false},
+		{"expires in the future", http.Header{"Expires": {now.Add(time.Hour).Format(http.TimeFormat)}}, now, true},
+		{"expires in the past", http.Header{"Expires": {now.Add(-time.Hour).Format(http.TimeFormat)}}, now, false},
+		{"no-cache", http.Header{"Cache-Control": {"No-Cache, max-age=60"}}, now, false},
+		{"no freshness information", http.Header{}, now, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &CacheEntry{StatusCode: http.StatusOK, Header: tt.header, StoredAt: tt.storedAt}
+			if got := entry.fresh(now); got != tt.want {
+				t.Errorf("fresh() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(2)
+	entry := func(body string) *CacheEntry { return &CacheEntry{Body: []byte(body)} }
+
+	cache.Set("a", entry("a"))
+	cache.Set("b", entry("b"))
+
+	// Using a makes b the least recently used entry
+	if got, ok := cache.Get("a"); !ok || string(got.Body) != "a" {
+		t.Fatalf("Get(a) = %v, %v, want a", got, ok)
+	}
+
+	cache.Set("c", entry("c"))
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) found evicted entry")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+
+	cache.Set("a", entry("a2"))
+	if got, _ := cache.Get("a"); string(got.Body) != "a2" {
+		t.Errorf("Get(a) = %q, want a2", got.Body)
+	}
+
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Get(a) found deleted entry")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Error("Get(c) missing")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir() + "/cache"
+
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache returned error: %v", err)
+	}
+
+	want := &CacheEntry{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": {`"v1"`}},
+		Body:       []byte(cachedServerBody),
+		StoredAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	key := "https://registry.example.com/v0.1/servers/test%2Fserver/versions/latest"
+	cache.Set(key, want)
+
+	// A second cache on the same directory sees the entry
+	reopened, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache returned error: %v", err)
+	}
+	got, ok := reopened.Get(key)
+	if !ok {
+		t.Fatal("Get() missing stored entry")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+
+	reopened.Delete(key)
+	if _, ok := cache.Get(key); ok {
+		t.Error("Get() found deleted entry")
+	}
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("Get(missing) found an entry")
+	}
+}
+
+func TestClient_Cache_Disk(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache returned error: %v", err)
+	}
+	client.Cache = cache
+
+	var requests int
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, cachedServerBody)
+	})
+
+	ctx := context.Background()
+	for range 2 {
+		server, _, err := client.Servers.Get(ctx, "test/server", nil)
+		if err != nil {
+			t.Fatalf("Servers.Get returned error: %v", err)
+		}
+		if server.Name != "test/server" {
+			t.Errorf("Servers.Get name = %q, want test/server", server.Name)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("registry received %d requests, want 1", requests)
+	}
+}
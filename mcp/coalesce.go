@@ -0,0 +1,142 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// flightGroup tracks the GET requests in flight for a Client, so that
+// identical requests issued concurrently share a single round-trip.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a request in flight shared by one or more callers.
+type flight struct {
+	done    chan struct{} // closed once the result is available
+	cancel  context.CancelFunc
+	waiters int // callers waiting for the result, guarded by flightGroup.mu
+
+	response *Response
+	attempts int
+	body     []byte
+	err      error
+}
+
+// flightHeaders are the request headers that change how the Client's Cache
+// answers a request, so requests differing in them never share a flight.
+var flightHeaders = []string{"Cache-Control", "If-None-Match", "If-Modified-Since"}
+
+// coalescedDo sends an API request, sharing the round-trip with identical
+// GET requests already in flight if CoalesceRequests is enabled. Requests
+// are identical if they have the same URL and flightHeaders.
+//
+// The shared request is detached from the context of the caller that
+// started it, so that one caller giving up does not fail the others. It is
+// canceled once every caller waiting for it has given up. Like retryDo, it
+// returns the number of attempts made, which callers sharing a round-trip
+// each report.
+func (c *Client) coalescedDo(ctx context.Context, req *http.Request) (*Response, int, error) {
+	if !c.CoalesceRequests || req.Method != http.MethodGet {
+		return c.cachedDo(ctx, req)
+	}
+
+	key := flightKey(req)
+	g := &c.flights
+
+	g.mu.Lock()
+	f, ok := g.flights[key]
+	if !ok {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		if g.flights == nil {
+			g.flights = make(map[string]*flight)
+		}
+		g.flights[key] = f
+		go c.fly(flightCtx, key, f, req.WithContext(flightCtx))
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	if ok {
+		c.log(ctx, slog.LevelDebug, "mcp: joined request in flight", slog.String("url", sanitizeURL(req.URL).String()))
+	}
+
+	select {
+	case <-f.done:
+		response, err := f.result()
+		return response, f.attempts, err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// Nobody is left waiting: abandon the request and let the next
+			// caller start a new one.
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, 0, ctx.Err()
+	}
+}
+
+// flightKey returns the key of the flight req may share: its method and URL,
+// followed by any flightHeaders it carries.
+func flightKey(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString(req.Method + " " + req.URL.String())
+	for _, h := range flightHeaders {
+		if values := req.Header.Values(h); len(values) > 0 {
+			b.WriteString("\n" + h + ": " + strings.Join(values, ", "))
+		}
+	}
+	return b.String()
+}
+
+// fly sends the request shared by the callers of f and records its result,
+// buffering the response body so each caller can read its own copy.
+func (c *Client) fly(ctx context.Context, key string, f *flight, req *http.Request) {
+	defer f.cancel()
+
+	response, attempts, err := c.cachedDo(ctx, req)
+	if err == nil {
+		f.body, err = io.ReadAll(response.Body)
+		response.Body.Close()
+	}
+	f.response, f.attempts, f.err = response, attempts, err
+
+	g := &c.flights
+	g.mu.Lock()
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+	g.mu.Unlock()
+
+	close(f.done)
+}
+
+// result returns a copy of the flight's response for one of its callers,
+// with its own headers and body reader.
+func (f *flight) result() (*Response, error) {
+	if f.response == nil {
+		return nil, f.err
+	}
+
+	resp := *f.response.Response
+	resp.Header = resp.Header.Clone()
+	if f.err == nil {
+		resp.Body = io.NopCloser(bytes.NewReader(f.body))
+	}
+
+	response := *f.response
+	response.Response = &resp
+	return &response, f.err
+}
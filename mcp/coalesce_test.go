@@ -0,0 +1,285 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// waitForWaiters blocks until n callers are waiting for the plain GET
+// request in flight for urlStr.
+func waitForWaiters(t *testing.T, c *Client, urlStr string, n int) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, urlStr, nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	key := flightKey(req)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		c.flights.mu.Lock()
+		f := c.flights.flights[key]
+		waiters := 0
+		if f != nil {
+			waiters = f.waiters
+		}
+		c.flights.mu.Unlock()
+
+		if waiters == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d callers of %s", n, urlStr)
+}
+
+func TestClient_CoalesceRequests(t *testing.T) {
+	const callers = 10
+
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	client.CoalesceRequests = true
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		fmt.Fprint(w, `{"server": {"name": "test/server", "version": "1.0.0"}}`)
+	})
+
+	var wg sync.WaitGroup
+	servers := make([]*registryv0.ServerJSON, callers)
+	responses := make([]*Response, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			servers[i], responses[i], errs[i] = client.Servers.GetByNameExactVersion(context.Background(), "test/server", "1.0.0")
+		}()
+	}
+
+	waitForWaiters(t, client, serverURL+"/v0.1/servers/test%2Fserver/versions/1.0.0", callers)
+	close(release)
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("registry received %d requests, want 1", got)
+	}
+
+	for i := range callers {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: GetByNameExactVersion returned error: %v", i, errs[i])
+		}
+		if servers[i].Name != "test/server" || servers[i].Version != "1.0.0" {
+			t.Errorf("caller %d: GetByNameExactVersion returned %+v", i, servers[i])
+		}
+		if responses[i].StatusCode != http.StatusOK {
+			t.Errorf("caller %d: StatusCode = %d, want %d", i, responses[i].StatusCode, http.StatusOK)
+		}
+	}
+
+	// Each caller decodes its own copy
+	servers[0].Description = "modified"
+	for i := 1; i < callers; i++ {
+		if servers[i] == servers[0] || servers[i].Description != "" {
+			t.Errorf("caller %d shares its server with caller 0", i)
+		}
+		if responses[i] == responses[0] || responses[i].Response == responses[0].Response {
+			t.Errorf("caller %d shares its response with caller 0", i)
+		}
+	}
+
+	// Once the flight has landed, new requests go upstream again
+	if _, _, err := client.Servers.GetByNameExactVersion(context.Background(), "test/server", "1.0.0"); err != nil {
+		t.Fatalf("GetByNameExactVersion returned error: %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("registry received %d requests, want 2", got)
+	}
+}
+
+func TestClient_CoalesceRequests_Error(t *testing.T) {
+	const callers = 5
+
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	client.CoalesceRequests = true
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404, "detail": "Server not found"}`)
+	})
+
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, errs[i] = client.Servers.Get(context.Background(), "test/server", nil)
+		}()
+	}
+
+	waitForWaiters(t, client, serverURL+"/v0.1/servers/test%2Fserver/versions/latest", callers)
+	close(release)
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("registry received %d requests, want 1", got)
+	}
+	for i, err := range errs {
+		if !IsNotFound(err) {
+			t.Errorf("caller %d: error = %v, want IsNotFound", i, err)
+		}
+	}
+}
+
+func TestClient_CoalesceRequests_DistinctRequests(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.CoalesceRequests = true
+
+	var hits atomic.Int32
+	mux.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		fmt.Fprint(w, `{"server": {"name": "test/server", "version": "1.0.0"}}`)
+	})
+
+	var wg sync.WaitGroup
+	for _, version := range []string{"1.0.0", "2.0.0", "3.0.0"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Servers.GetByNameExactVersion(context.Background(), "test/server", version); err != nil {
+				t.Errorf("GetByNameExactVersion(%s) returned error: %v", version, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := hits.Load(); got != 3 {
+		t.Errorf("registry received %d requests, want 3", got)
+	}
+}
+
+func TestClient_CoalesceRequests_CacheHeaders(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	client.CoalesceRequests = true
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		fmt.Fprint(w, `{"server": {"name": "test/server", "version": "1.0.0"}}`)
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if _, _, err := client.Servers.Get(context.Background(), "test/server", nil); err != nil {
+			t.Errorf("Get returned error: %v", err)
+		}
+	}()
+	waitForWaiters(t, client, serverURL+"/v0.1/servers/test%2Fserver/versions/latest", 1)
+
+	// A no-cache request does not join the plain request in flight
+	go func() {
+		defer wg.Done()
+		if _, _, err := client.Servers.getWithMeta(context.Background(), "test/server", nil, true); err != nil {
+			t.Errorf("getWithMeta returned error: %v", err)
+		}
+	}()
+	deadline := time.Now().Add(2 * time.Second)
+	for hits.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := hits.Load(); got != 2 {
+		t.Errorf("registry received %d requests, want 2", got)
+	}
+}
+
+func TestClient_CoalesceRequests_Cancellation(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	client.CoalesceRequests = true
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	upstreamCanceled := make(chan struct{})
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			<-release
+			fmt.Fprint(w, `{"server": {"name": "test/server", "version": "1.0.0"}}`)
+			return
+		}
+		<-r.Context().Done()
+		close(upstreamCanceled)
+	})
+	key := serverURL + "/v0.1/servers/test%2Fserver/versions/latest"
+
+	// The caller that started the request giving up does not fail the
+	// other callers.
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, _, err := client.Servers.Get(firstCtx, "test/server", nil)
+		firstErr <- err
+	}()
+	waitForWaiters(t, client, key, 1)
+
+	secondErr := make(chan error, 1)
+	go func() {
+		_, _, err := client.Servers.Get(context.Background(), "test/server", nil)
+		secondErr <- err
+	}()
+	waitForWaiters(t, client, key, 2)
+
+	cancelFirst()
+	if err := <-firstErr; err != context.Canceled {
+		t.Errorf("canceled caller error = %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	if err := <-secondErr; err != nil {
+		t.Errorf("remaining caller returned error: %v", err)
+	}
+
+	// Once every caller has given up, the request is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, _, err := client.Servers.Get(ctx, "test/server", nil)
+		errc <- err
+	}()
+	waitForWaiters(t, client, key, 1)
+	cancel()
+
+	if err := <-errc; err != context.Canceled {
+		t.Errorf("canceled caller error = %v, want %v", err, context.Canceled)
+	}
+	select {
+	case <-upstreamCanceled:
+	case <-time.After(2 * time.Second):
+		t.Error("upstream request was not canceled")
+	}
+}
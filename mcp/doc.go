@@ -7,21 +7,57 @@
 //
 // # Features
 //
-// The SDK currently provides read-only operations for the MCP Registry:
+// The SDK provides the following operations for the MCP Registry:
 //
 //   - List servers with pagination, search, and filtering
 //   - Get server details by name with version support
 //   - Cursor-based pagination support
 //   - Rate limit tracking from response headers
+//   - Automatic retries with exponential backoff
+//   - Response caching with ETag and Last-Modified revalidation
 //   - Context support for all API calls
 //   - Comprehensive error handling
 //   - Helper methods for common operations
+//   - Publishing servers with a registry JWT
+//   - Deprecating, deleting and restoring published versions
 //
 // # Authentication
 //
-// The MCP Registry API currently supports read-only operations without
-// authentication. Future versions of this SDK will support authentication
-// for write operations such as publishing and updating servers.
+// Read operations do not require authentication. Write operations such as
+// publishing a server require a registry JWT, which the client sends as a
+// bearer token with every request other than GET and HEAD. The Auth service
+// exchanges GitHub, OIDC, DNS or HTTP credentials for a registry JWT and
+// caches it on the client until it expires:
+//
+//	_, _, err := client.Auth.ExchangeGitHubAccessToken(ctx, githubToken)
+//
+// DNS and HTTP authentication prove control of a domain by signing the
+// current time with a private key. ParsePrivateKeyPEM and ParsePrivateKeyHex
+// load Ed25519 and ECDSA P-384 keys, and DNSTXTRecord and WellKnownAuthFile
+// generate the public key record the domain must publish:
+//
+//	signer, err := mcp.ParsePrivateKeyPEM(pemBytes)
+//	fmt.Println(mcp.DNSTXTRecord("example.com", signer))
+//	_, _, err = client.Auth.ExchangeDNSWithSigner(ctx, "example.com", signer)
+//
+// A token obtained elsewhere can be set directly:
+//
+//	client.SetAuthToken(registryJWT)
+//	published, _, err := client.Servers.Publish(ctx, &serverJSON)
+//	if mcp.IsValidation(err) {
+//		// inspect err.(*mcp.ErrorResponse).Errors
+//	}
+//
+// The validate subpackage checks a server.json offline before publishing:
+//
+//	if err := validate.ServerJSON(&serverJSON).Err(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Versions can be deprecated, deleted, or restored from deprecated to active
+// with a token that has edit permissions:
+//
+//	_, _, err := client.Servers.UpdateStatus(ctx, name, "1.0.0", model.StatusDeprecated)
 //
 // # Usage
 //
@@ -40,6 +76,19 @@
 //	}
 //	client := mcp.NewClient(httpClient)
 //
+// Or configure the client with functional options, which are validated
+// when the client is created:
+//
+//	client, err := mcp.New(
+//		mcp.WithBaseURL("https://registry.example.com"),
+//		mcp.WithUserAgent("my-app/1.0"),
+//		mcp.WithTimeout(60*time.Second),
+//		mcp.WithRetryPolicy(mcp.DefaultRetryPolicy()),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
 // List servers:
 //
 //	servers, resp, err := client.Servers.List(context.Background(), nil)
@@ -114,6 +163,15 @@
 //		}
 //	}
 //
+// Registry metadata (status, publishedAt, updatedAt, isLatest) is dropped by
+// the methods above, which return ServerJSON. Every helper has a WithMeta
+// variant returning the full ServerResponse instead:
+//
+//	serverResp, _, err := client.Servers.GetWithMeta(context.Background(), "ai.waystation/gmail", nil)
+//	if err == nil && serverResp.Meta.Official != nil {
+//		fmt.Printf("Status: %s\n", serverResp.Meta.Official.Status)
+//	}
+//
 // # Pagination
 //
 // The API uses cursor-based pagination following the MCP Protocol specification.
@@ -141,6 +199,18 @@
 //
 //	servers, _, err := client.Servers.ListAll(context.Background(), nil)
 //
+// To stream results without buffering every page, range over an iterator.
+// Pages are fetched lazily and fetching stops when the loop exits early:
+//
+//	for server, err := range client.Servers.Iter(context.Background(), opts) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(server.Server.Name)
+//	}
+//
+// IterPages yields whole pages together with their Response instead.
+//
 // # Error Handling
 //
 // The library provides structured error handling with custom error types:
@@ -159,6 +229,24 @@
 //		log.Fatal(err)
 //	}
 //
+// Common failures can be classified without type assertions, using
+// errors.Is with the sentinel errors ErrNotFound, ErrUnauthorized,
+// ErrForbidden, ErrConflict and ErrServerUnavailable, or the matching helpers:
+//
+//	_, _, err := client.Servers.Get(context.Background(), "ai.waystation/gmail", nil)
+//	if mcp.IsNotFound(err) {
+//		fmt.Println("server does not exist")
+//	}
+//
+// Error bodies in RFC 9457 problem+json format, as returned by the registry,
+// are decoded into ErrorResponse.Title, Status and Detail, and each entry of
+// ErrorResponse.Errors carries the Location (such as "body.version") and
+// Value of the offending input:
+//
+//	for _, e := range apiErr.Errors {
+//		fmt.Printf("%s: %s\n", e.Location, e.Message)
+//	}
+//
 // # Rate Limiting
 //
 // Rate limit information is tracked and available in response objects:
@@ -170,6 +258,105 @@
 //		fmt.Printf("Reset at: %v\n", resp.Rate.Reset)
 //	}
 //
+// The most recent limits for every requested path are available from
+// Client.RateLimits. To avoid sending requests that are bound to be rejected,
+// enable WaitForRateLimit, and optionally add a client-side token bucket:
+//
+//	client.WaitForRateLimit = true
+//	client.RateLimiter = mcp.NewTokenBucket(5, 10) // 5 req/s, bursts of 10
+//
+// # Logging
+//
+// Set a Logger to log each request attempt, retry, rate limit wait and cache
+// hit. Successful requests are logged at debug level; failed ones at info or
+// warn level. Authorization headers and credentials in URLs are redacted:
+//
+//	client.Logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+//
+// # Middleware
+//
+// Middleware registered with Client.Use runs around every request attempt
+// the client sends, outermost first, and may modify the request, inspect the
+// Response and error, or answer the request itself:
+//
+//	client.Use(func(next mcp.RoundTripFunc) mcp.RoundTripFunc {
+//		return func(req *http.Request) (*mcp.Response, error) {
+//			req = req.Clone(req.Context())
+//			req.Header.Set("X-Tenant", "acme")
+//			return next(req)
+//		}
+//	})
+//
+// # Metrics
+//
+// Set Metrics to receive measurements of every request, keyed by route
+// templates such as "v0.1/servers/{name}/versions/{version}" rather than
+// URLs. ExpvarMetrics publishes them with the expvar package:
+//
+//	client.Metrics = mcp.NewExpvarMetrics("mcp_registry")
+//
+// # Tracing
+//
+// Set Tracer to start a span for every request and for operations made of
+// several requests, such as Servers.ListAll, whose pages become child spans.
+// Requests carry a W3C traceparent header identifying their span.
+// SpanRecorder keeps spans in memory for tests:
+//
+//	client.Tracer = mcp.NewSpanRecorder()
+//
+// # Failover
+//
+// Set Endpoints, or use WithEndpoints, to fail reads over between several
+// registries in order of preference. An endpoint that fails with a transport
+// error or 5xx status is skipped for EndpointCooldown, and Response.Endpoint
+// records which registry served each response:
+//
+//	client, err := mcp.New(mcp.WithEndpoints(mirrorURL, "https://registry.modelcontextprotocol.io"))
+//
+// # Federation
+//
+// A Federation queries several Clients as one, merging their results,
+// keeping one copy of each server version from the registry with the
+// highest priority and annotating each server with its Source registry.
+// NamespaceRules restrict namespaces to specific registries:
+//
+//	federation, err := mcp.NewFederation(registries,
+//		mcp.NamespaceRule{Pattern: "com.acme/*", Registries: []string{"internal"}})
+//
+// # Retries
+//
+// By default each request is attempted once. Set a RetryPolicy to retry
+// idempotent requests that fail with a transport error or a retryable status
+// code (429, 500, 502, 503 and 504 by default):
+//
+//	client := mcp.NewClient(nil)
+//	client.RetryPolicy = mcp.DefaultRetryPolicy()
+//
+// Delays requested by the API through Retry-After or X-RateLimit-Reset are
+// honored as long as they do not exceed RetryPolicy.MaxBackoff.
+//
+// # Caching
+//
+// Set a Cache to store GET responses. MemoryCache keeps a bounded number of
+// responses in memory, and DiskCache stores them as files in a directory:
+//
+//	client.Cache = mcp.NewMemoryCache(1000)
+//
+// Responses are served from the cache without contacting the API while they
+// are fresh according to their Cache-Control max-age or Expires headers.
+// Stale responses are revalidated with If-None-Match and If-Modified-Since,
+// and a 304 Not Modified reply is served from the cache. Response.FromCache
+// reports whether a response came from the cache. Requests carrying a
+// Cache-Control: no-cache header are always revalidated, as are the checks
+// made by Client.Probe, and successful edits and publishes remove the cached
+// responses they make stale.
+//
+// Enable CoalesceRequests to make identical GET requests issued concurrently
+// share a single round-trip, with each caller decoding its own copy of the
+// response:
+//
+//	client.CoalesceRequests = true
+//
 // # Service Architecture
 //
 // The client follows a service-oriented architecture where different API
@@ -177,6 +364,8 @@
 //
 //	// Available services
 //	client.Servers  // Server-related operations
+//	client.Auth     // Registry JWT exchange
+//	client.Meta     // Health, ping and version
 //
 // Each service provides methods for different operations:
 //
@@ -184,11 +373,43 @@
 //	List(ctx, opts) (*ServerListResponse, *Response, error)
 //	Get(ctx, name, opts) (*ServerJSON, *Response, error)
 //	ListVersionsByName(ctx, name) ([]ServerJSON, *Response, error)
+//	Iter(ctx, opts) iter.Seq2[ServerResponse, error]                           // Helper - streams all pages lazily
+//	IterPages(ctx, opts) iter.Seq2[*ServerPage, error]                         // Helper - streams pages lazily
 //	ListAll(ctx, opts) ([]ServerJSON, *Response, error)                        // Helper - fetches all pages
 //	ListByUpdatedSince(ctx, since) ([]ServerJSON, *Response, error)            // Helper - filters by update time
-//	GetLatestVersion(ctx, name) (*ServerJSON, *Response, error)                // Helper - latest version via API
+//	GetLatestVersion(ctx, name) (*ServerJSON, *Response, error)                // Helper - latest version via versions endpoint
 //	GetExactVersion(ctx, name, version) (*ServerJSON, *Response, error)        // Helper - specific version via API
-//	GetLatestActiveVersion(ctx, name) (*ServerJSON, *Response, error)          // Helper - latest active by semver
+//	GetLatestActiveVersion(ctx, name) (*ServerJSON, *Response, error)          // Helper - latest active by CompareVersions
+//	Resolve(ctx, name, constraint, opts) (*Resolution, *Response, error)      // Helper - best match for a semver constraint
+//
+//	Publish(ctx, server) (*ServerResponse, *Response, error)                   // Requires a registry JWT
+//	Edit(ctx, name, version, server, opts) (*ServerResponse, *Response, error) // Requires edit permissions
+//	UpdateStatus(ctx, name, version, status) (*ServerResponse, *Response, error)
+//
+//	// Each method returning ServerJSON has a WithMeta variant returning ServerResponse
+//	GetWithMeta(ctx, name, opts) (*ServerResponse, *Response, error)
+//	ListAllWithMeta(ctx, opts) ([]ServerResponse, *Response, error)
+//	// MetaService methods
+//	Health(ctx) (*HealthResponse, *Response, error)
+//	Ping(ctx) (*PingResponse, *Response, error)
+//	Version(ctx) (*VersionResponse, *Response, error)
+//
+// Client.Probe combines a health check and a version lookup to report
+// whether a registry is reachable, its latency and the version it runs:
+//
+//	result, err := client.Probe(ctx)
+//	fmt.Println(result.Reachable, result.Latency, result.Version)
+//
+// # Version Ordering
+//
+// The registry accepts versions that are not semantic versions, such as dates
+// or commit hashes. CompareVersions orders semantic versions by precedence,
+// ranks them above other versions, and orders other versions by publish time.
+// Client.VersionComparator replaces the strategy, and Client.SortVersions
+// applies it to a version history:
+//
+//	versions, _, err := client.Servers.ListVersionsByNameWithMeta(ctx, name)
+//	client.SortVersions(versions) // newest first
 //
 // # Type Reuse
 //
@@ -2,17 +2,47 @@ package mcp
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+)
+
+// Sentinel errors for common classes of API failures. Errors returned by API
+// methods match them with errors.Is according to the response status code:
+//
+//	_, _, err := client.Servers.Get(ctx, "ai.waystation/gmail", nil)
+//	if errors.Is(err, mcp.ErrNotFound) {
+//		// handle missing server
+//	}
+var (
+	ErrValidation        = errors.New("mcp: validation failed")  // 400 Bad Request, 422 Unprocessable Entity
+	ErrNotFound          = errors.New("mcp: not found")          // 404 Not Found
+	ErrUnauthorized      = errors.New("mcp: unauthorized")       // 401 Unauthorized
+	ErrForbidden         = errors.New("mcp: forbidden")          // 403 Forbidden
+	ErrConflict          = errors.New("mcp: conflict")           // 409 Conflict
+	ErrServerUnavailable = errors.New("mcp: server unavailable") // any 5xx status
 )
 
 // ErrorResponse represents an error response from the MCP Registry API.
+//
+// The registry reports errors as RFC 9457 problem details
+// (application/problem+json), which populate Title, Status, Detail and the
+// Location and Value of each entry in Errors. Plain JSON error bodies with a
+// message field are supported as well.
 type ErrorResponse struct {
 	Response *http.Response // HTTP response that caused this error
 	Message  string         `json:"message,omitempty"`
 	Errors   []Error        `json:"errors,omitempty"`
+
+	// RFC 9457 problem details
+	Type     string `json:"type,omitempty"`     // URI identifying the problem type
+	Title    string `json:"title,omitempty"`    // Short, human-readable summary of the problem type
+	Status   int    `json:"status,omitempty"`   // HTTP status code reported by the API
+	Detail   string `json:"detail,omitempty"`   // Explanation specific to this occurrence
+	Instance string `json:"instance,omitempty"` // URI identifying this occurrence
 }
 
 // Error represents a single error detail in an API response.
@@ -21,24 +51,103 @@ type Error struct {
 	Field    string `json:"field,omitempty"`    // Field on which the error occurred
 	Code     string `json:"code,omitempty"`     // Validation error code
 	Message  string `json:"message,omitempty"`  // Message describing the error
+	Location string `json:"location,omitempty"` // Location of the offending value, such as "body.name"
+	Value    any    `json:"value,omitempty"`    // Offending value, if reported
 }
 
 func (r *ErrorResponse) Error() string {
-	if r.Message != "" {
-		return fmt.Sprintf("%v %v: %d %v",
-			r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
-			r.Response.StatusCode, r.Message)
+	s := fmt.Sprintf("%v %v: %d",
+		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
+		r.Response.StatusCode)
+
+	summary := r.summary()
+	if summary != "" {
+		s += " " + summary
 	}
 
 	if len(r.Errors) > 0 {
-		return fmt.Sprintf("%v %v: %d %+v",
-			r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
-			r.Response.StatusCode, r.Errors)
+		details := make([]string, len(r.Errors))
+		for i, e := range r.Errors {
+			details[i] = e.describe()
+		}
+		if summary != "" {
+			s += ":"
+		}
+		s += " " + strings.Join(details, "; ")
 	}
 
-	return fmt.Sprintf("%v %v: %d",
-		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
-		r.Response.StatusCode)
+	return s
+}
+
+// Is reports whether target is the sentinel error matching the status code
+// of the response, such as ErrNotFound for a 404.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.Response == nil {
+		return false
+	}
+	sentinel := sentinelForStatus(r.Response.StatusCode)
+	return sentinel != nil && target == sentinel
+}
+
+// sentinelForStatus returns the sentinel error for the given HTTP status
+// code, or nil if there is none.
+func sentinelForStatus(code int) error {
+	switch {
+	case code == http.StatusBadRequest, code == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case code == http.StatusForbidden:
+		return ErrForbidden
+	case code == http.StatusConflict:
+		return ErrConflict
+	case code >= 500 && code <= 599:
+		return ErrServerUnavailable
+	}
+	return nil
+}
+
+// summary returns the most specific top-level description of the error:
+// the message, the problem detail, or the problem title.
+func (r *ErrorResponse) summary() string {
+	switch {
+	case r.Message != "":
+		return r.Message
+	case r.Detail != "":
+		return r.Detail
+	default:
+		return r.Title
+	}
+}
+
+// describe renders an error detail as "location: message", followed by the
+// error code and offending value when present.
+func (e Error) describe() string {
+	location := e.Location
+	if location == "" {
+		var parts []string
+		for _, p := range []string{e.Resource, e.Field} {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+		location = strings.Join(parts, ".")
+	}
+
+	s := e.Message
+	if location != "" {
+		s = location + ": " + s
+	}
+	if e.Code != "" {
+		s += fmt.Sprintf(" (code: %s)", e.Code)
+	}
+	if e.Value != nil {
+		s += fmt.Sprintf(" (value: %v)", e.Value)
+	}
+
+	return s
 }
 
 // RateLimitError occurs when the API rate limit is exceeded.
@@ -69,10 +178,43 @@ func (r *RateLimitError) Is(target error) bool {
 		sanitizeURL(r.Response.Request.URL) == sanitizeURL(v.Response.Request.URL)
 }
 
+// IsValidation reports whether err was caused by the API rejecting the
+// request as invalid, with a 400 Bad Request or 422 Unprocessable Entity
+// response. The details are available in ErrorResponse.Errors.
+func IsValidation(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+// IsNotFound reports whether err was caused by a 404 Not Found response.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err was caused by a 401 Unauthorized response.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether err was caused by a 403 Forbidden response.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsConflict reports whether err was caused by a 409 Conflict response.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsServerUnavailable reports whether err was caused by a 5xx response.
+func IsServerUnavailable(err error) bool {
+	return errors.Is(err, ErrServerUnavailable)
+}
+
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if it has a status code outside the 200 range.
 // API error responses are expected to have either no response body, or a JSON
-// response body that maps to ErrorResponse.
+// or problem+json response body that maps to ErrorResponse. The returned
+// error matches the sentinel errors, such as ErrNotFound, with errors.Is.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
@@ -92,7 +234,7 @@ func CheckResponse(r *http.Response) error {
 		return &RateLimitError{
 			Rate:     parseRate(r),
 			Response: r,
-			Message:  errorResponse.Message,
+			Message:  errorResponse.summary(),
 		}
 	}
 
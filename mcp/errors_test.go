@@ -2,6 +2,9 @@ package mcp
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -48,7 +51,49 @@ func TestErrorResponse_Error(t *testing.T) {
 					},
 				},
 			},
-			want: "POST https://api.example.com/v0.1/servers: 422 [{Resource:Server Field:name Code:invalid Message:name is invalid}]",
+			want: "POST https://api.example.com/v0.1/servers: 422 Server.name: name is invalid (code: invalid)",
+		},
+		{
+			name: "problem details with validation errors",
+			response: &ErrorResponse{
+				Response: &http.Response{
+					StatusCode: 422,
+					Request: &http.Request{
+						Method: "POST",
+						URL:    mustParseURL("https://api.example.com/v0.1/publish"),
+					},
+				},
+				Title:  "Unprocessable Entity",
+				Status: 422,
+				Detail: "validation failed",
+				Errors: []Error{
+					{
+						Message:  "expected length <= 100",
+						Location: "body.description",
+						Value:    "a very long description",
+					},
+					{
+						Message:  "expected required property version to be present",
+						Location: "body",
+					},
+				},
+			},
+			want: "POST https://api.example.com/v0.1/publish: 422 validation failed: body.description: expected length <= 100 (value: a very long description); body: expected required property version to be present",
+		},
+		{
+			name: "problem details with title only",
+			response: &ErrorResponse{
+				Response: &http.Response{
+					StatusCode: 404,
+					Request: &http.Request{
+						Method: "GET",
+						URL:    mustParseURL("https://api.example.com/v0.1/servers/test"),
+					},
+				},
+				Title:  "Not Found",
+				Status: 404,
+			},
+			want: "GET https://api.example.com/v0.1/servers/test: 404 Not Found",
 		},
 		{
 			name: "error with only status code",
@@ -89,6 +134,83 @@ func TestErrorResponse_Error(t *testing.T) {
 	}
 }
 
+func TestCheckResponse_ProblemDetails(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: 422,
+		Request: &http.Request{
+			Method: "POST",
+			URL:    mustParseURL("https://api.example.com/v0.1/publish"),
+		},
+		Header: http.Header{"Content-Type": []string{"application/problem+json"}},
+		Body: io.NopCloser(bytes.NewBufferString(`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "validation failed",
+			"instance": "/v0.1/publish",
+			"errors": [
+				{"message": "expected string", "location": "body.name", "value": 42},
+				{"message": "unexpected property", "location": "body.extra"}
+			]
+		}`)),
+	}
+
+	err := CheckResponse(resp)
+	errResp, ok := err.(*ErrorResponse)
+	if !ok {
+		t.Fatalf("CheckResponse() error type = %T, want *ErrorResponse", err)
+	}
+
+	if errResp.Type != "about:blank" {
+		t.Errorf("Type = %q, want %q", errResp.Type, "about:blank")
+	}
+	if errResp.Title != "Unprocessable Entity" {
+		t.Errorf("Title = %q, want %q", errResp.Title, "Unprocessable Entity")
+	}
+	if errResp.Status != 422 {
+		t.Errorf("Status = %d, want %d", errResp.Status, 422)
+	}
+	if errResp.Detail != "validation failed" {
+		t.Errorf("Detail = %q, want %q", errResp.Detail, "validation failed")
+	}
+	if errResp.Instance != "/v0.1/publish" {
+		t.Errorf("Instance = %q, want %q", errResp.Instance, "/v0.1/publish")
+	}
+
+	wantErrors := []Error{
+		{Message: "expected string", Location: "body.name", Value: float64(42)},
+		{Message: "unexpected property", Location: "body.extra"},
+	}
+	if len(errResp.Errors) != len(wantErrors) {
+		t.Fatalf("Errors = %+v, want %+v", errResp.Errors, wantErrors)
+	}
+	for i, want := range wantErrors {
+		if got := errResp.Errors[i]; got != want {
+			t.Errorf("Errors[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestCheckResponse_RateLimitProblemDetail(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Request: &http.Request{
+			Method: "GET",
+			URL:    mustParseURL("https://api.example.com/v0.1/servers"),
+		},
+		Body: io.NopCloser(bytes.NewBufferString(`{"title": "Too Many Requests", "status": 429, "detail": "slow down"}`)),
+	}
+
+	err := CheckResponse(resp)
+	rateErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("CheckResponse() error type = %T, want *RateLimitError", err)
+	}
+	if rateErr.Message != "slow down" {
+		t.Errorf("RateLimitError.Message = %q, want %q", rateErr.Message, "slow down")
+	}
+}
+
 func TestRateLimitError_Error(t *testing.T) {
 	resetTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 
@@ -323,6 +445,26 @@ func TestCheckResponse(t *testing.T) {
 			wantErrType:    "ErrorResponse",
 			wantErrMessage: "GET https://api.example.com/v0.1/servers: 404",
 		},
+		{
+			name: "problem+json error",
+			response: &http.Response{
+				StatusCode: 400,
+				Request: &http.Request{
+					Method: "POST",
+					URL:    mustParseURL("https://api.example.com/v0.1/publish"),
+				},
+				Header: http.Header{"Content-Type": []string{"application/problem+json"}},
+				Body: io.NopCloser(bytes.NewBufferString(`{
+					"title": "Bad Request",
+					"status": 400,
+					"detail": "Failed to publish server",
+					"errors": [{"message": "invalid version range", "location": "body.version", "value": "^1.0"}]
+				}`)),
+			},
+			wantErr:        true,
+			wantErrType:    "ErrorResponse",
+			wantErrMessage: "POST https://api.example.com/v0.1/publish: 400 Failed to publish server: body.version: invalid version range (value: ^1.0)",
+		},
 		{
 			name: "error with errors array",
 			response: &http.Response{
@@ -388,3 +530,79 @@ func mustParseURLWithUser(rawURL string) *url.URL {
 	}
 	return u
 }
+
+func TestCheckResponse_SentinelErrors(t *testing.T) {
+	sentinels := []error{ErrValidation, ErrNotFound, ErrUnauthorized, ErrForbidden, ErrConflict, ErrServerUnavailable}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		want       error
+		helper     func(error) bool
+	}{
+		{name: "400 bad request", statusCode: http.StatusBadRequest, want: ErrValidation, helper: IsValidation},
+		{name: "401 unauthorized", statusCode: http.StatusUnauthorized, want: ErrUnauthorized, helper: IsUnauthorized},
+		{name: "403 forbidden", statusCode: http.StatusForbidden, want: ErrForbidden, helper: IsForbidden},
+		{name: "404 not found", statusCode: http.StatusNotFound, want: ErrNotFound, helper: IsNotFound},
+		{name: "409 conflict", statusCode: http.StatusConflict, want: ErrConflict, helper: IsConflict},
+		{name: "422 unprocessable", statusCode: http.StatusUnprocessableEntity, want: ErrValidation, helper: IsValidation},
+		{name: "429 rate limited", statusCode: http.StatusTooManyRequests, want: nil},
+		{name: "500 internal error", statusCode: http.StatusInternalServerError, want: ErrServerUnavailable, helper: IsServerUnavailable},
+		{name: "502 bad gateway", statusCode: http.StatusBadGateway, want: ErrServerUnavailable, helper: IsServerUnavailable},
+		{name: "503 service unavailable", statusCode: http.StatusServiceUnavailable, want: ErrServerUnavailable, helper: IsServerUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckResponse(&http.Response{
+				StatusCode: tt.statusCode,
+				Request: &http.Request{
+					Method: "GET",
+					URL:    mustParseURL("https://api.example.com/v0.1/servers"),
+				},
+				Body: io.NopCloser(bytes.NewBufferString(`{"title": "error"}`)),
+			})
+			if err == nil {
+				t.Fatal("CheckResponse() expected error, got nil")
+			}
+
+			// Wrapping must not hide the classification.
+			wrapped := fmt.Errorf("fetching servers: %w", err)
+
+			for _, sentinel := range sentinels {
+				want := sentinel == tt.want
+				if got := errors.Is(wrapped, sentinel); got != want {
+					t.Errorf("errors.Is(err, %v) = %v, want %v", sentinel, got, want)
+				}
+			}
+
+			if tt.helper != nil && !tt.helper(wrapped) {
+				t.Errorf("classification helper for %v returned false", tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorHelpers_NonAPIErrors(t *testing.T) {
+	for _, err := range []error{nil, errors.New("boom"), &ErrorResponse{}} {
+		if IsValidation(err) || IsNotFound(err) || IsUnauthorized(err) || IsForbidden(err) || IsConflict(err) || IsServerUnavailable(err) {
+			t.Errorf("classification helper matched non-API error %v", err)
+		}
+	}
+}
+
+func TestServersService_Get_NotFoundSentinel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404, "detail": "Server not found"}`)
+	})
+
+	_, _, err := client.Servers.Get(context.Background(), "missing/server", nil)
+	if !IsNotFound(err) {
+		t.Errorf("Servers.Get error = %v, want IsNotFound", err)
+	}
+}
@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultEndpointCooldown is how long a failed endpoint is skipped when the
+// Client's EndpointCooldown is zero.
+const DefaultEndpointCooldown = 30 * time.Second
+
+// failoverSend sends a single attempt of an API request through the
+// middleware chain. If the Client has Endpoints, reads are sent to the first
+// healthy one and, if it fails with a transport error or a 5xx status, to
+// each following endpoint in turn. Writes are always sent to BaseURL.
+func (c *Client) failoverSend(req *http.Request) (*Response, error) {
+	endpoints := c.readEndpoints(req)
+	if len(endpoints) == 0 {
+		response, err := c.send(req)
+		if response != nil {
+			response.Endpoint = c.BaseURL
+		}
+		return response, err
+	}
+
+	var response *Response
+	var err error
+	for i, endpoint := range endpoints {
+		var endpointReq *http.Request
+		endpointReq, err = c.rebase(req, endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err = c.send(endpointReq)
+		if response != nil {
+			response.Endpoint = endpoint
+		}
+		if !endpointFailed(req.Context(), response, err) {
+			c.markEndpoint(endpoint, true)
+			return response, err
+		}
+
+		c.markEndpoint(endpoint, false)
+		if i < len(endpoints)-1 {
+			c.log(req.Context(), slog.LevelWarn, "mcp: failing over",
+				slog.String("endpoint", endpoint.String()),
+				slog.String("next_endpoint", endpoints[i+1].String()),
+				slog.Duration("cooldown", c.endpointCooldown()),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return response, err
+}
+
+// readEndpoints returns the endpoints to try, in order, for a read request:
+// the healthy Endpoints or, if none is healthy, all of them. It returns nil
+// if req should be sent as is.
+func (c *Client) readEndpoints(req *http.Request) []*url.URL {
+	if len(c.Endpoints) == 0 || c.BaseURL == nil {
+		return nil
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil
+	}
+	if !strings.HasPrefix(req.URL.String(), c.BaseURL.String()) {
+		return nil
+	}
+
+	now := time.Now()
+	c.endpointMu.Lock()
+	defer c.endpointMu.Unlock()
+
+	var healthy []*url.URL
+	for _, endpoint := range c.Endpoints {
+		if now.After(c.endpointDown[endpoint.String()]) {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	if len(healthy) == 0 {
+		return c.Endpoints
+	}
+	return healthy
+}
+
+// rebase returns req addressed to endpoint rather than BaseURL.
+func (c *Client) rebase(req *http.Request, endpoint *url.URL) (*http.Request, error) {
+	if endpoint.String() == c.BaseURL.String() {
+		return req, nil
+	}
+
+	rel := strings.TrimPrefix(req.URL.EscapedPath(), c.BaseURL.EscapedPath())
+	u, err := endpoint.Parse(rel)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = req.URL.RawQuery
+
+	req = req.Clone(req.Context())
+	req.URL = u
+	req.Host = u.Host
+	return req, nil
+}
+
+// markEndpoint records whether a request to endpoint succeeded. A failed
+// endpoint is skipped until its cooldown has passed, after which it is tried
+// again and stays in use if it succeeds.
+func (c *Client) markEndpoint(endpoint *url.URL, ok bool) {
+	c.endpointMu.Lock()
+	defer c.endpointMu.Unlock()
+
+	if ok {
+		delete(c.endpointDown, endpoint.String())
+		return
+	}
+	if c.endpointDown == nil {
+		c.endpointDown = make(map[string]time.Time)
+	}
+	c.endpointDown[endpoint.String()] = time.Now().Add(c.endpointCooldown())
+}
+
+func (c *Client) endpointCooldown() time.Duration {
+	if c.EndpointCooldown > 0 {
+		return c.EndpointCooldown
+	}
+	return DefaultEndpointCooldown
+}
+
+// endpointFailed reports whether a request failed in a way that warrants
+// trying another endpoint: a transport error or a 5xx status. Client errors
+// and cancellation of the request's context do not.
+func endpointFailed(ctx context.Context, resp *Response, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if resp == nil || resp.Response == nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
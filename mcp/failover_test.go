@@ -0,0 +1,190 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// failoverRegistry is a registry endpoint counting the requests it serves.
+type failoverRegistry struct {
+	*httptest.Server
+	status int
+	hits   int
+	paths  []string
+}
+
+// newFailoverRegistry starts a registry answering every request with status
+// under the given path prefix.
+func newFailoverRegistry(t *testing.T, prefix string, status int) *failoverRegistry {
+	t.Helper()
+	r := &failoverRegistry{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.hits++
+		r.paths = append(r.paths, req.URL.EscapedPath())
+		w.WriteHeader(r.status)
+		if r.status == http.StatusOK {
+			fmt.Fprint(w, `{"server": {"name": "test/server", "version": "1.0.0"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"title": %q, "status": %d}`, http.StatusText(r.status), r.status)
+	}))
+	t.Cleanup(r.Close)
+	r.URL += prefix
+	return r
+}
+
+func newFailoverClient(t *testing.T, opts []ClientOption, endpoints ...*failoverRegistry) *Client {
+	t.Helper()
+	var urls []string
+	for _, e := range endpoints {
+		urls = append(urls, e.URL)
+	}
+	client, err := New(append([]ClientOption{WithEndpoints(urls...)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return client
+}
+
+func TestClient_Failover(t *testing.T) {
+	primary := newFailoverRegistry(t, "/", http.StatusServiceUnavailable)
+	mirror := newFailoverRegistry(t, "/mirror/", http.StatusOK)
+	client := newFailoverClient(t, []ClientOption{WithEndpointCooldown(50 * time.Millisecond)}, primary, mirror)
+
+	server, resp, err := client.Servers.Get(context.Background(), "test/server", nil)
+	if err != nil {
+		t.Fatalf("Servers.Get returned error: %v", err)
+	}
+	if server.Name != "test/server" {
+		t.Errorf("Servers.Get returned %q, want %q", server.Name, "test/server")
+	}
+	if got := resp.Endpoint.String(); got != mirror.URL {
+		t.Errorf("Endpoint = %q, want %q", got, mirror.URL)
+	}
+	if want := "/mirror/v0.1/servers/test%2Fserver/versions/latest"; mirror.paths[0] != want {
+		t.Errorf("mirror path = %q, want %q", mirror.paths[0], want)
+	}
+
+	// The primary is skipped while its circuit is open
+	if _, _, err := client.Servers.Get(context.Background(), "test/server", nil); err != nil {
+		t.Fatalf("Servers.Get returned error: %v", err)
+	}
+	if primary.hits != 1 || mirror.hits != 2 {
+		t.Errorf("hits = %d primary and %d mirror, want 1 and 2", primary.hits, mirror.hits)
+	}
+
+	// and tried again once the cooldown has passed
+	time.Sleep(60 * time.Millisecond)
+	primary.status = http.StatusOK
+	_, resp, err = client.Servers.Get(context.Background(), "test/server", nil)
+	if err != nil {
+		t.Fatalf("Servers.Get returned error: %v", err)
+	}
+	if got := resp.Endpoint.String(); got != primary.URL {
+		t.Errorf("Endpoint = %q after cooldown, want %q", got, primary.URL)
+	}
+	if primary.hits != 2 || mirror.hits != 2 {
+		t.Errorf("hits = %d primary and %d mirror, want 2 and 2", primary.hits, mirror.hits)
+	}
+}
+
+func TestClient_Failover_TransportError(t *testing.T) {
+	primary := newFailoverRegistry(t, "/", http.StatusOK)
+	primary.Close()
+	mirror := newFailoverRegistry(t, "/", http.StatusOK)
+	client := newFailoverClient(t, nil, primary, mirror)
+
+	_, resp, err := client.Servers.Get(context.Background(), "test/server", nil)
+	if err != nil {
+		t.Fatalf("Servers.Get returned error: %v", err)
+	}
+	if got := resp.Endpoint.String(); got != mirror.URL {
+		t.Errorf("Endpoint = %q, want %q", got, mirror.URL)
+	}
+}
+
+func TestClient_Failover_NotOnClientError(t *testing.T) {
+	primary := newFailoverRegistry(t, "/", http.StatusNotFound)
+	mirror := newFailoverRegistry(t, "/", http.StatusOK)
+	client := newFailoverClient(t, nil, primary, mirror)
+
+	_, resp, err := client.Servers.Get(context.Background(), "test/server", nil)
+	if !IsNotFound(err) {
+		t.Fatalf("Servers.Get error = %v, want IsNotFound", err)
+	}
+	if got := resp.Endpoint.String(); got != primary.URL {
+		t.Errorf("Endpoint = %q, want %q", got, primary.URL)
+	}
+	if mirror.hits != 0 {
+		t.Errorf("mirror hits = %d, want 0", mirror.hits)
+	}
+}
+
+func TestClient_Failover_AllUnhealthy(t *testing.T) {
+	primary := newFailoverRegistry(t, "/", http.StatusBadGateway)
+	mirror := newFailoverRegistry(t, "/", http.StatusServiceUnavailable)
+	client := newFailoverClient(t, nil, primary, mirror)
+
+	for range 2 {
+		_, resp, err := client.Servers.Get(context.Background(), "test/server", nil)
+		if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("Servers.Get = %v, %v, want the mirror's 503", resp, err)
+		}
+	}
+
+	// With every circuit open, all endpoints are still tried in order
+	if primary.hits != 2 || mirror.hits != 2 {
+		t.Errorf("hits = %d primary and %d mirror, want 2 and 2", primary.hits, mirror.hits)
+	}
+}
+
+func TestClient_Failover_WritesUseBaseURL(t *testing.T) {
+	primary := newFailoverRegistry(t, "/", http.StatusServiceUnavailable)
+	mirror := newFailoverRegistry(t, "/", http.StatusOK)
+	client := newFailoverClient(t, []ClientOption{WithAuthToken("token")}, primary, mirror)
+
+	_, _, err := client.Servers.Publish(context.Background(), &registryv0.ServerJSON{Name: "test/server", Version: "1.0.0"})
+	if err == nil {
+		t.Fatal("Servers.Publish expected error, got nil")
+	}
+	if primary.hits != 1 || mirror.hits != 0 {
+		t.Errorf("hits = %d primary and %d mirror, want 1 and 0", primary.hits, mirror.hits)
+	}
+}
+
+func TestResponse_Endpoint(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	_, resp, err := client.Meta.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Meta.Ping returned error: %v", err)
+	}
+	if resp.Endpoint != client.BaseURL {
+		t.Errorf("Endpoint = %v, want BaseURL %v", resp.Endpoint, client.BaseURL)
+	}
+}
+
+func TestWithEndpoints(t *testing.T) {
+	client, err := New(WithEndpoints("https://mirror.example.com/registry", "https://registry.example.com"))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	if got, want := client.BaseURL.String(), "https://mirror.example.com/registry/"; got != want {
+		t.Errorf("BaseURL = %q, want %q", got, want)
+	}
+	if len(client.Endpoints) != 2 || client.Endpoints[1].String() != "https://registry.example.com/" {
+		t.Errorf("Endpoints = %v, want the mirror and registry.example.com", client.Endpoints)
+	}
+}
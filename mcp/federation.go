@@ -0,0 +1,243 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sync"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Federation queries several registries as one, such as a private registry
+// of internal servers alongside the public registry. Results from every
+// registry are merged, keeping a single copy of each server version, taken
+// from the registry with the highest priority, and annotated with the
+// registry it came from.
+//
+// NamespaceRules restrict which registries may provide servers in a
+// namespace, so that a public server cannot shadow an internal one:
+//
+//	federation, err := mcp.NewFederation(
+//		[]mcp.FederatedRegistry{
+//			{Name: "internal", Client: internalClient, Priority: 10},
+//			{Name: "public", Client: publicClient},
+//		},
+//		mcp.NamespaceRule{Pattern: "com.acme/*", Registries: []string{"internal"}},
+//	)
+//
+// A Federation is safe for concurrent use if its Clients are.
+type Federation struct {
+	registries []FederatedRegistry // highest priority first
+	rules      []NamespaceRule
+}
+
+// FederatedRegistry is a registry taking part in a Federation.
+type FederatedRegistry struct {
+	// Name identifies the registry in NamespaceRules and is reported as the
+	// Source of its servers.
+	Name string
+
+	// Client is used to query the registry.
+	Client *Client
+
+	// Priority decides which registry a server version is taken from when
+	// several registries provide it; the highest wins. Registries of equal
+	// priority rank in the order they were given to NewFederation.
+	Priority int
+}
+
+// NamespaceRule restricts the servers whose names match Pattern to the
+// registries listed in Registries. Servers from other registries whose names
+// match Pattern are ignored. Rules are checked in order and only the first
+// matching rule applies; servers matching no rule may come from any registry.
+type NamespaceRule struct {
+	// Pattern matches server names with the syntax of path.Match, such as
+	// "com.acme/*" for every server in the com.acme namespace.
+	Pattern string
+
+	// Registries lists the Names of the registries allowed to provide
+	// matching servers.
+	Registries []string
+}
+
+// FederatedServer is a server returned by a Federation, together with the
+// registry it came from.
+type FederatedServer struct {
+	registryv0.ServerResponse
+
+	// Source is the Name of the FederatedRegistry that provided the server.
+	Source string
+}
+
+// NewFederation returns a Federation of registries, governed by rules.
+// Registry names must be unique and non-empty, and rules may only refer to
+// the registries given.
+func NewFederation(registries []FederatedRegistry, rules ...NamespaceRule) (*Federation, error) {
+	if len(registries) == 0 {
+		return nil, errors.New("at least one registry is required")
+	}
+
+	names := make(map[string]bool)
+	for _, r := range registries {
+		if r.Name == "" {
+			return nil, errors.New("registry name must not be empty")
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("duplicate registry name %q", r.Name)
+		}
+		if r.Client == nil {
+			return nil, fmt.Errorf("registry %q has a nil client", r.Name)
+		}
+		names[r.Name] = true
+	}
+
+	for _, rule := range rules {
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid namespace pattern %q: %w", rule.Pattern, err)
+		}
+		for _, name := range rule.Registries {
+			if !names[name] {
+				return nil, fmt.Errorf("namespace rule %q refers to unknown registry %q", rule.Pattern, name)
+			}
+		}
+	}
+
+	sorted := slices.Clone(registries)
+	slices.SortStableFunc(sorted, func(a, b FederatedRegistry) int {
+		return b.Priority - a.Priority
+	})
+
+	return &Federation{registries: sorted, rules: slices.Clone(rules)}, nil
+}
+
+// ListAll fetches all pages of servers matching opts from every registry and
+// merges them, in order of registry priority. If some registries fail, the
+// servers from the others are returned along with an error describing each
+// failure.
+//
+// Cursors belong to a single registry, so opts.Cursor must be empty; ListAll
+// returns an error rather than send it to every registry.
+func (f *Federation) ListAll(ctx context.Context, opts *ServerListOptions) ([]FederatedServer, error) {
+	if opts != nil && opts.Cursor != "" {
+		return nil, errors.New("cursor is not supported across registries")
+	}
+
+	results, err := f.query(ctx, f.registries, func(ctx context.Context, client *Client) ([]registryv0.ServerResponse, error) {
+		servers, _, err := client.Servers.ListAllWithMeta(ctx, opts)
+		return servers, err
+	})
+
+	return f.merge(f.registries, results), err
+}
+
+// Get retrieves the server with the given name from the registry with the
+// highest priority that is allowed to provide it and has it. By default the
+// latest version is returned; set opts.Version to request a specific one.
+//
+// A registry that does not have the server is skipped, while any other
+// error is returned without consulting lower priority registries. If no
+// registry has the server, the returned error satisfies IsNotFound.
+func (f *Federation) Get(ctx context.Context, name string, opts *ServerGetOptions) (*FederatedServer, error) {
+	for _, r := range f.allowed(name) {
+		server, _, err := r.Client.Servers.GetWithMeta(ctx, name, opts)
+		if IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("registry %s: %w", r.Name, err)
+		}
+		if server == nil {
+			continue
+		}
+		return &FederatedServer{ServerResponse: *server, Source: r.Name}, nil
+	}
+
+	return nil, fmt.Errorf("server %q not found in any registry: %w", name, ErrNotFound)
+}
+
+// ListVersionsByName retrieves the versions of the server with the given
+// name from every registry allowed to provide it and merges them, in order
+// of registry priority. Registries that do not have the server contribute
+// no versions. If some registries fail, the versions from the others are
+// returned along with an error describing each failure.
+func (f *Federation) ListVersionsByName(ctx context.Context, name string) ([]FederatedServer, error) {
+	registries := f.allowed(name)
+	results, err := f.query(ctx, registries, func(ctx context.Context, client *Client) ([]registryv0.ServerResponse, error) {
+		versions, _, err := client.Servers.versionsByName(ctx, name)
+		return versions, err
+	})
+
+	return f.merge(registries, results), err
+}
+
+// allowed returns the registries allowed to provide the server with the
+// given name, highest priority first.
+func (f *Federation) allowed(name string) []FederatedRegistry {
+	var registries []FederatedRegistry
+	for _, r := range f.registries {
+		if f.allows(r.Name, name) {
+			registries = append(registries, r)
+		}
+	}
+	return registries
+}
+
+// allows reports whether the registry called registry may provide the
+// server with the given name.
+func (f *Federation) allows(registry, name string) bool {
+	for _, rule := range f.rules {
+		if ok, _ := path.Match(rule.Pattern, name); ok {
+			return slices.Contains(rule.Registries, registry)
+		}
+	}
+	return true
+}
+
+// query calls fn concurrently for each registry and returns the results in
+// the same order as registries, with the errors of failed registries joined.
+func (f *Federation) query(ctx context.Context, registries []FederatedRegistry, fn func(ctx context.Context, client *Client) ([]registryv0.ServerResponse, error)) ([][]registryv0.ServerResponse, error) {
+	results := make([][]registryv0.ServerResponse, len(registries))
+	errs := make([]error, len(registries))
+
+	var wg sync.WaitGroup
+	for i, r := range registries {
+		wg.Go(func() {
+			results[i], errs[i] = fn(ctx, r.Client)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("registry %s: %w", r.Name, errs[i])
+			}
+		})
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+// merge annotates results with the registry each came from, dropping
+// servers that registry may not provide and versions already provided by a
+// registry of higher priority.
+func (f *Federation) merge(registries []FederatedRegistry, results [][]registryv0.ServerResponse) []FederatedServer {
+	seen := make(map[string]bool)
+	var merged []FederatedServer
+
+	for i, r := range registries {
+		for _, server := range results[i] {
+			if !f.allows(r.Name, server.Server.Name) {
+				continue
+			}
+
+			key := server.Server.Name + "@" + server.Server.Version
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			merged = append(merged, FederatedServer{ServerResponse: server, Source: r.Name})
+		}
+	}
+
+	return merged
+}
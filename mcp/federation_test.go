@@ -0,0 +1,273 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// newTestFederation returns a Federation of an "internal" registry with
+// priority 10 and a "public" one, where only internal may provide servers
+// in the com.acme namespace.
+func newTestFederation(t *testing.T) (federation *Federation, internal, public *http.ServeMux) {
+	t.Helper()
+	internalClient, internal, _, internalTeardown := setup()
+	t.Cleanup(internalTeardown)
+	publicClient, public, _, publicTeardown := setup()
+	t.Cleanup(publicTeardown)
+
+	federation, err := NewFederation(
+		[]FederatedRegistry{
+			{Name: "public", Client: publicClient},
+			{Name: "internal", Client: internalClient, Priority: 10},
+		},
+		NamespaceRule{Pattern: "com.acme/*", Registries: []string{"internal"}},
+	)
+	if err != nil {
+		t.Fatalf("NewFederation returned error: %v", err)
+	}
+	return federation, internal, public
+}
+
+// federatedSources returns "name@version source" for each server.
+func federatedSources(servers []FederatedServer) []string {
+	var got []string
+	for _, s := range servers {
+		got = append(got, fmt.Sprintf("%s@%s %s", s.Server.Name, s.Server.Version, s.Source))
+	}
+	return got
+}
+
+func TestFederation_ListAll(t *testing.T) {
+	federation, internal, public := newTestFederation(t)
+
+	internal.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"search": "tools"})
+		fmt.Fprint(w, `{"servers": [
+			{"server": {"name": "com.acme/tools", "version": "1.0.0"}},
+			{"server": {"name": "io.github.shared/tools", "version": "2.0.0", "description": "internal"}}
+		], "metadata": {}}`)
+	})
+	public.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"search": "tools"})
+		fmt.Fprint(w, `{"servers": [
+			{"server": {"name": "com.acme/tools", "version": "9.9.9"}},
+			{"server": {"name": "io.github.shared/tools", "version": "2.0.0", "description": "public"}},
+			{"server": {"name": "io.github.shared/tools", "version": "2.1.0"}},
+			{"server": {"name": "io.github.other/tools", "version": "1.0.0"}}
+		], "metadata": {}}`)
+	})
+
+	servers, err := federation.ListAll(context.Background(), &ServerListOptions{Search: "tools"})
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+
+	want := []string{
+		"com.acme/tools@1.0.0 internal",
+		"io.github.shared/tools@2.0.0 internal",
+		"io.github.shared/tools@2.1.0 public",
+		"io.github.other/tools@1.0.0 public",
+	}
+	if got := federatedSources(servers); !reflect.DeepEqual(got, want) {
+		t.Errorf("ListAll returned %v, want %v", got, want)
+	}
+	if servers[1].Server.Description != "internal" {
+		t.Errorf("duplicate version taken from %q, want the internal registry", servers[1].Server.Description)
+	}
+}
+
+func TestFederation_ListAll_PartialFailure(t *testing.T) {
+	federation, internal, public := newTestFederation(t)
+
+	internal.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"title": "Service Unavailable", "status": 503}`)
+	})
+	public.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers": [{"server": {"name": "io.github.other/tools", "version": "1.0.0"}}], "metadata": {}}`)
+	})
+
+	servers, err := federation.ListAll(context.Background(), nil)
+	if !IsServerUnavailable(err) {
+		t.Errorf("ListAll error = %v, want IsServerUnavailable", err)
+	}
+	if want := []string{"io.github.other/tools@1.0.0 public"}; !reflect.DeepEqual(federatedSources(servers), want) {
+		t.Errorf("ListAll returned %v, want %v", federatedSources(servers), want)
+	}
+}
+
+func TestFederation_ListAll_Cursor(t *testing.T) {
+	federation, internal, public := newTestFederation(t)
+
+	for _, mux := range []*http.ServeMux{internal, public} {
+		mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+			t.Error("registry consulted with a cursor")
+		})
+	}
+
+	_, err := federation.ListAll(context.Background(), &ServerListOptions{ListOptions: ListOptions{Cursor: "page2"}})
+	if want := "cursor is not supported across registries"; err == nil || err.Error() != want {
+		t.Errorf("ListAll error = %v, want %q", err, want)
+	}
+}
+
+func TestFederation_Get(t *testing.T) {
+	federation, internal, public := newTestFederation(t)
+
+	internal.HandleFunc("/v0.1/servers/io.github.shared%2Fonly-public/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404}`)
+	})
+	internal.HandleFunc("/v0.1/servers/io.github.shared%2Fboth/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"server": {"name": "io.github.shared/both", "version": "1.0.0"}}`)
+	})
+	internal.HandleFunc("/v0.1/servers/com.acme%2Fmissing/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404}`)
+	})
+	public.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"server": {"name": "any", "version": "1.0.0"}}`)
+	})
+
+	tests := []struct {
+		name       string
+		serverName string
+		opts       *ServerGetOptions
+		wantSource string
+		wantErr    bool
+	}{
+		{"falls through to public", "io.github.shared/only-public", nil, "public", false},
+		{"highest priority wins", "io.github.shared/both", &ServerGetOptions{Version: "1.0.0"}, "internal", false},
+		{"namespace restricted", "com.acme/missing", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := federation.Get(context.Background(), tt.serverName, tt.opts)
+			if tt.wantErr {
+				if !IsNotFound(err) {
+					t.Errorf("Get error = %v, want IsNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get returned error: %v", err)
+			}
+			if server.Source != tt.wantSource {
+				t.Errorf("Source = %q, want %q", server.Source, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestFederation_Get_Error(t *testing.T) {
+	federation, internal, public := newTestFederation(t)
+
+	internal.HandleFunc("/v0.1/servers/io.github.shared%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"title": "Internal Server Error", "status": 500}`)
+	})
+	public.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("lower priority registry consulted after an error")
+	})
+
+	if _, err := federation.Get(context.Background(), "io.github.shared/server", nil); !IsServerUnavailable(err) {
+		t.Errorf("Get error = %v, want IsServerUnavailable", err)
+	}
+}
+
+func TestFederation_ListVersionsByName(t *testing.T) {
+	federation, internal, public := newTestFederation(t)
+
+	internal.HandleFunc("/v0.1/servers/io.github.shared%2Fserver/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers": [{"server": {"name": "io.github.shared/server", "version": "1.0.0"}}], "metadata": {}}`)
+	})
+	public.HandleFunc("/v0.1/servers/io.github.shared%2Fserver/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers": [
+			{"server": {"name": "io.github.shared/server", "version": "1.0.0"}},
+			{"server": {"name": "io.github.shared/server", "version": "1.1.0"}}
+		], "metadata": {}}`)
+	})
+	public.HandleFunc("/v0.1/servers/com.acme%2Ftools/versions", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("public registry consulted for a restricted namespace")
+	})
+	internal.HandleFunc("/v0.1/servers/com.acme%2Ftools/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404}`)
+	})
+
+	versions, err := federation.ListVersionsByName(context.Background(), "io.github.shared/server")
+	if err != nil {
+		t.Fatalf("ListVersionsByName returned error: %v", err)
+	}
+	want := []string{
+		"io.github.shared/server@1.0.0 internal",
+		"io.github.shared/server@1.1.0 public",
+	}
+	if got := federatedSources(versions); !reflect.DeepEqual(got, want) {
+		t.Errorf("ListVersionsByName returned %v, want %v", got, want)
+	}
+
+	versions, err = federation.ListVersionsByName(context.Background(), "com.acme/tools")
+	if err != nil {
+		t.Fatalf("ListVersionsByName returned error: %v", err)
+	}
+	if len(versions) != 0 {
+		t.Errorf("ListVersionsByName returned %v, want none", federatedSources(versions))
+	}
+}
+
+func TestNewFederation_Invalid(t *testing.T) {
+	client := NewClient(nil)
+
+	tests := []struct {
+		name       string
+		registries []FederatedRegistry
+		rules      []NamespaceRule
+		wantErrMsg string
+	}{
+		{
+			name:       "no registries",
+			wantErrMsg: "at least one registry is required",
+		},
+		{
+			name:       "empty name",
+			registries: []FederatedRegistry{{Client: client}},
+			wantErrMsg: "registry name must not be empty",
+		},
+		{
+			name:       "duplicate name",
+			registries: []FederatedRegistry{{Name: "a", Client: client}, {Name: "a", Client: client}},
+			wantErrMsg: `duplicate registry name "a"`,
+		},
+		{
+			name:       "nil client",
+			registries: []FederatedRegistry{{Name: "a"}},
+			wantErrMsg: `registry "a" has a nil client`,
+		},
+		{
+			name:       "invalid pattern",
+			registries: []FederatedRegistry{{Name: "a", Client: client}},
+			rules:      []NamespaceRule{{Pattern: "com.acme/[", Registries: []string{"a"}}},
+			wantErrMsg: `invalid namespace pattern "com.acme/[": syntax error in pattern`,
+		},
+		{
+			name:       "unknown registry",
+			registries: []FederatedRegistry{{Name: "a", Client: client}},
+			rules:      []NamespaceRule{{Pattern: "com.acme/*", Registries: []string{"b"}}},
+			wantErrMsg: `namespace rule "com.acme/*" refers to unknown registry "b"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFederation(tt.registries, tt.rules...)
+			if err == nil || err.Error() != tt.wantErrMsg {
+				t.Errorf("NewFederation error = %v, want %q", err, tt.wantErrMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// redactedHeaders lists the request headers whose values are never logged.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization"}
+
+// log writes a log record to the Client's Logger, if any.
+func (c *Client) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if c.Logger == nil {
+		return
+	}
+	c.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logAttempt logs the outcome of a single attempt of an API request.
+//
+// Successful requests are logged at debug level. Requests that failed
+// because of the client, such as a 404 Not Found, are logged at info level,
+// and those that may succeed when retried, such as transport errors, 429 Too
+// Many Requests and 5xx responses, at warn level.
+func (c *Client) logAttempt(ctx context.Context, req *http.Request, attempt int, duration time.Duration, resp *Response, err error) {
+	if c.Logger == nil {
+		return
+	}
+
+	attrs := append(requestAttrs(req),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	)
+
+	level := slog.LevelDebug
+	if resp != nil && resp.Response != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if resp.Rate.Limit > 0 {
+			attrs = append(attrs, slog.Group("rate",
+				slog.Int("limit", resp.Rate.Limit),
+				slog.Int("remaining", resp.Rate.Remaining),
+				slog.Time("reset", resp.Rate.Reset),
+			))
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			level = slog.LevelWarn
+		case resp.StatusCode >= 400:
+			level = slog.LevelInfo
+		}
+	} else if err != nil {
+		level = slog.LevelWarn
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	c.Logger.LogAttrs(ctx, level, "mcp: request", attrs...)
+}
+
+// requestAttrs returns the attributes identifying a request in log records:
+// its method, sanitized URL, pagination cursor and redacted headers.
+func requestAttrs(req *http.Request) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", sanitizeURL(req.URL).String()),
+	}
+	if cursor := req.URL.Query().Get("cursor"); cursor != "" {
+		attrs = append(attrs, slog.String("cursor", cursor))
+	}
+	attrs = append(attrs, slog.Any("headers", redactHeaders(req.Header)))
+	return attrs
+}
+
+// redactHeaders returns a copy of header with the values of credentials
+// replaced by "REDACTED".
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,223 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// captureLogs sets a JSON logger at debug level on client and returns a
+// function decoding the records logged so far.
+func captureLogs(t *testing.T, client *Client) func() []map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+	client.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	return func() []map[string]any {
+		t.Helper()
+		var records []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var record map[string]any
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				t.Fatalf("decoding log record %q: %v", line, err)
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+}
+
+func TestClient_Logger_Request(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	logs := captureLogs(t, client)
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("X-RateLimit-Reset", "2024-01-01T00:00:00Z")
+		fmt.Fprint(w, `{"servers": [{"server": {"name": "test/server", "version": "1.0.0"}}], "metadata": {"nextCursor": "page3"}}`)
+	})
+
+	opts := &ServerListOptions{ListOptions: ListOptions{Cursor: "page2"}}
+	if _, _, err := client.Servers.List(context.Background(), opts); err != nil {
+		t.Fatalf("Servers.List returned error: %v", err)
+	}
+
+	records := logs()
+	if len(records) != 2 {
+		t.Fatalf("logged %d records, want 2: %v", len(records), records)
+	}
+
+	request := records[0]
+	want := map[string]any{
+		"level":   "DEBUG",
+		"msg":     "mcp: request",
+		"method":  "GET",
+		"url":     client.BaseURL.String() + "v0.1/servers?cursor=page2",
+		"cursor":  "page2",
+		"attempt": float64(1),
+		"status":  float64(200),
+	}
+	for k, v := range want {
+		if request[k] != v {
+			t.Errorf("request record %s = %v, want %v", k, request[k], v)
+		}
+	}
+	if _, ok := request["duration"]; !ok {
+		t.Error("request record missing duration")
+	}
+	rate, _ := request["rate"].(map[string]any)
+	if rate["limit"] != float64(100) || rate["remaining"] != float64(99) {
+		t.Errorf("request record rate = %v, want limit 100 and remaining 99", request["rate"])
+	}
+
+	page := records[1]
+	if page["msg"] != "mcp: listed servers" || page["cursor"] != "page2" || page["next_cursor"] != "page3" || page["count"] != float64(1) {
+		t.Errorf("page record = %v, want cursor page2, next_cursor page3 and count 1", page)
+	}
+}
+
+func TestClient_Logger_Redaction(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	logs := captureLogs(t, client)
+
+	client.BaseURL, _ = url.Parse(strings.Replace(serverURL, "http://", "http://user:secret@", 1) + "/")
+	client.SetAuthToken("registry-jwt")
+
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"server": {"name": "test/server", "version": "1.0.0"}}`)
+	})
+
+	server := &registryv0.ServerJSON{Name: "test/server", Version: "1.0.0"}
+	if _, _, err := client.Servers.Publish(context.Background(), server); err != nil {
+		t.Fatalf("Servers.Publish returned error: %v", err)
+	}
+
+	records := logs()
+	if len(records) != 1 {
+		t.Fatalf("logged %d records, want 1: %v", len(records), records)
+	}
+
+	encoded, _ := json.Marshal(records)
+	for _, secret := range []string{"registry-jwt", "secret"} {
+		if strings.Contains(string(encoded), secret) {
+			t.Errorf("log records contain %q: %s", secret, encoded)
+		}
+	}
+
+	headers, _ := records[0]["headers"].(map[string]any)
+	if got := fmt.Sprint(headers["Authorization"]); got != "[REDACTED]" {
+		t.Errorf("logged Authorization header = %s, want [REDACTED]", got)
+	}
+	if got := records[0]["url"]; !strings.Contains(fmt.Sprint(got), "REDACTED:REDACTED@") {
+		t.Errorf("logged url = %v, want redacted user info", got)
+	}
+}
+
+func TestClient_Logger_Retry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	logs := captureLogs(t, client)
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+
+	attempts := 0
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"server": {"name": "test/server", "version": "1.0.0"}}`)
+	})
+
+	if _, _, err := client.Servers.Get(context.Background(), "test/server", nil); err != nil {
+		t.Fatalf("Servers.Get returned error: %v", err)
+	}
+
+	var got []string
+	for _, record := range logs() {
+		got = append(got, fmt.Sprintf("%s %s %v", record["level"], record["msg"], record["attempt"]))
+	}
+	want := []string{
+		"WARN mcp: request 1",
+		"INFO mcp: retrying request 2",
+		"DEBUG mcp: request 2",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("log records =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestClient_Logger_Levels(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusOK, "DEBUG"},
+		{http.StatusNotFound, "INFO"},
+		{http.StatusTooManyRequests, "WARN"},
+		{http.StatusInternalServerError, "WARN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			logs := captureLogs(t, client)
+
+			mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"pong": true}`)
+			})
+
+			client.Meta.Ping(context.Background())
+
+			records := logs()
+			if len(records) != 1 {
+				t.Fatalf("logged %d records, want 1", len(records))
+			}
+			if records[0]["level"] != tt.want {
+				t.Errorf("level = %v, want %s", records[0]["level"], tt.want)
+			}
+			if tt.status != http.StatusOK && records[0]["error"] == nil {
+				t.Error("record missing error")
+			}
+		})
+	}
+}
+
+func TestClient_Logger_TransportError(t *testing.T) {
+	client, _, _, teardown := setup()
+	logs := captureLogs(t, client)
+	teardown()
+
+	if _, _, err := client.Meta.Ping(context.Background()); err == nil {
+		t.Fatal("Meta.Ping expected error, got nil")
+	}
+
+	records := logs()
+	if len(records) != 1 {
+		t.Fatalf("logged %d records, want 1", len(records))
+	}
+	if records[0]["level"] != "WARN" || records[0]["error"] == nil {
+		t.Errorf("record = %v, want WARN with error", records[0])
+	}
+	if _, ok := records[0]["status"]; ok {
+		t.Errorf("record = %v, want no status", records[0])
+	}
+}
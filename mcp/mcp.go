@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -24,6 +25,8 @@ const (
 // provided, a new http.Client will be used. To use API methods which require
 // authentication, provide an http.Client that will perform the authentication
 // for you (such as that provided by the golang.org/x/oauth2 library).
+//
+// To configure the client with validated options instead, use New.
 func NewClient(httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{
@@ -42,6 +45,8 @@ func NewClient(httpClient *http.Client) *Client {
 
 	c.common.client = c
 	c.Servers = (*ServersService)(&c.common)
+	c.Auth = (*AuthService)(&c.common)
+	c.Meta = (*MetaService)(&c.common)
 
 	return c
 }
@@ -50,7 +55,8 @@ func NewClient(httpClient *http.Client) *Client {
 // in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash. If
 // specified, the value pointed to by body is JSON encoded and included as the
-// request body.
+// request body. Requests other than GET and HEAD carry the registry JWT set
+// with SetAuthToken, if any, in the Authorization header.
 func (c *Client) NewRequest(method, urlStr string, body any) (*http.Request, error) {
 	if !strings.HasSuffix(c.BaseURL.Path, "/") {
 		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
@@ -83,6 +89,11 @@ func (c *Client) NewRequest(method, urlStr string, body any) (*http.Request, err
 	if c.UserAgent != "" {
 		req.Header.Set("User-Agent", c.UserAgent)
 	}
+	if method != http.MethodGet && method != http.MethodHead {
+		if token := c.authToken(); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
 
 	return req, nil
 }
@@ -119,6 +130,19 @@ func parseRate(r *http.Response) Rate {
 // the raw response body will be written to v, without attempting to first
 // decode it.
 //
+// Before each attempt, Do blocks as required by the Client's RateLimiter and,
+// if WaitForRateLimit is enabled, until an exhausted rate limit window resets.
+// If the Client has a RetryPolicy, idempotent requests that fail with a
+// transport error or a retryable status code are retried according to it.
+// Each attempt passes through the middleware registered with Use and, if the
+// Client has Endpoints, reads fail over between them. If the
+// Client has Metrics, the request is reported to it once Do returns. If the
+// Client has a Tracer, Do runs in a span of its own, whose traceparent header
+// is sent with each attempt.
+// If the Client has a Cache, GET responses are served from and stored in it.
+// If CoalesceRequests is enabled, identical GET requests issued concurrently
+// share a single round-trip.
+//
 // The provided ctx must be non-nil. If it is canceled or times out,
 // ctx.Err() will be returned.
 func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, error) {
@@ -126,11 +150,93 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, e
 		return nil, fmt.Errorf("context must be non-nil")
 	}
 
-	req = req.WithContext(ctx)
+	route := c.route(req)
+	ctx, span := c.startSpan(ctx, req.Method+" "+route)
+	span.SetAttribute("http.request.method", req.Method)
+	span.SetAttribute("http.route", route)
+	span.SetAttribute("url.full", sanitizeURL(req.URL).String())
+
+	response, err := c.do(ctx, injectTraceParent(req.WithContext(ctx), span), v)
+	if response != nil && response.Response != nil {
+		span.SetAttribute("http.response.status_code", response.StatusCode)
+	}
+	endSpan(span, err)
+
+	return response, err
+}
+
+// do implements Do for a request carrying its span.
+func (c *Client) do(ctx context.Context, req *http.Request, v any) (*Response, error) {
+	start := time.Now()
+	if c.Metrics != nil {
+		c.Metrics.RequestStarted(req.Method, c.route(req))
+	}
+
+	response, attempts, err := c.coalescedDo(ctx, req)
+	if err != nil {
+		c.recordRequest(req, start, response, attempts, 0, err)
+		return response, err
+	}
+	defer response.Body.Close()
+
+	body := &countingReader{r: response.Body}
+	if v != nil {
+		if w, ok := v.(io.Writer); ok {
+			io.Copy(w, body)
+		} else {
+			decErr := json.NewDecoder(body).Decode(v)
+			if decErr == io.EOF {
+				decErr = nil // ignore EOF errors caused by empty response body
+			}
+			if decErr != nil {
+				err = decErr
+			}
+		}
+	}
+	c.recordRequest(req, start, response, attempts, body.n, err)
+
+	return response, err
+}
+
+// retryDo sends an API request through the middleware chain, waiting for
+// rate limits before each attempt and retrying it according to the Client's
+// RetryPolicy. It returns the number of attempts made along with the
+// result of the last one.
+func (c *Client) retryDo(ctx context.Context, req *http.Request) (*Response, int, error) {
+	var response *Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err := c.waitForRateLimit(ctx, req); err != nil {
+			return response, attempt - 1, err
+		}
+
+		start := time.Now()
+		response, err = c.failoverSend(req)
+		c.logAttempt(ctx, req, attempt, time.Since(start), response, err)
+
+		delay, retry := c.RetryPolicy.retryDelay(req, attempt, response, err)
+		if !retry {
+			return response, attempt, err
+		}
+
+		c.log(ctx, slog.LevelInfo, "mcp: retrying request",
+			slog.String("method", req.Method),
+			slog.String("url", sanitizeURL(req.URL).String()),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("delay", delay),
+		)
 
-	c.clientMu.Lock()
+		if err := sleep(ctx, delay); err != nil {
+			return response, attempt, err
+		}
+	}
+}
+
+// bareDo sends a single attempt of an API request. On success the body of
+// the returned Response is left open for the caller to consume and close;
+// on an API error it has already been read and closed.
+func (c *Client) bareDo(ctx context.Context, req *http.Request) (*Response, error) {
 	resp, err := c.client.Do(req)
-	c.clientMu.Unlock()
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
@@ -141,7 +247,6 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, e
 		}
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	response := newResponse(resp)
 
@@ -150,26 +255,17 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, e
 	c.rateLimits[req.URL.Path] = response.Rate
 	c.rateMu.Unlock()
 
-	err = CheckResponse(resp)
-	if err != nil {
-		return response, err
+	// A conditional request sent to revalidate a cached response
+	if resp.StatusCode == http.StatusNotModified && isConditional(req) {
+		return response, nil
 	}
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			io.Copy(w, resp.Body)
-		} else {
-			decErr := json.NewDecoder(resp.Body).Decode(v)
-			if decErr == io.EOF {
-				decErr = nil // ignore EOF errors caused by empty response body
-			}
-			if decErr != nil {
-				err = decErr
-			}
-		}
+	if err := CheckResponse(resp); err != nil {
+		resp.Body.Close()
+		return response, err
 	}
 
-	return response, err
+	return response, nil
 }
 
 // addOptions adds the parameters in opts as URL query parameters to s.
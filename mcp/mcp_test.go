@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -111,6 +112,38 @@ func TestNewRequest(t *testing.T) {
 	}
 }
 
+func TestNewRequest_AuthToken(t *testing.T) {
+	c := NewClient(nil)
+	c.SetAuthToken("registry-jwt")
+
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{method: http.MethodGet, want: ""},
+		{method: http.MethodHead, want: ""},
+		{method: http.MethodPost, want: "Bearer registry-jwt"},
+		{method: http.MethodPut, want: "Bearer registry-jwt"},
+		{method: http.MethodDelete, want: "Bearer registry-jwt"},
+	}
+
+	for _, tt := range tests {
+		req, err := c.NewRequest(tt.method, "v0.1/publish", nil)
+		if err != nil {
+			t.Fatalf("NewRequest(%s) unexpected error: %v", tt.method, err)
+		}
+		if got := req.Header.Get("Authorization"); got != tt.want {
+			t.Errorf("NewRequest(%s) Authorization = %q, want %q", tt.method, got, tt.want)
+		}
+	}
+
+	c.SetAuthToken("")
+	req, _ := c.NewRequest(http.MethodPost, "v0.1/publish", nil)
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("NewRequest() after clearing token Authorization = %q, want none", got)
+	}
+}
+
 func TestNewRequest_BadJSON(t *testing.T) {
 	c := NewClient(nil)
 
@@ -459,3 +492,77 @@ func TestNewResponse(t *testing.T) {
 		t.Errorf("newResponse() Rate.Reset = %v, want %v", resp.Rate.Reset, resetTime)
 	}
 }
+
+func TestDo_ConcurrentRequestsOverlap(t *testing.T) {
+	const concurrency = 8
+
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+	)
+	arrived := make(chan struct{}, concurrency)
+	release := make(chan struct{})
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handler := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+
+			arrived <- struct{}{}
+			select {
+			case <-release:
+			case <-time.After(2 * time.Second):
+			}
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+
+			fmt.Fprint(w, body)
+		}
+	}
+	mux.HandleFunc("/v0.1/servers", handler(`{"servers": [], "metadata": {}}`))
+	mux.HandleFunc("/v0.1/servers/", handler(`{"server": {"name": "test/server", "version": "1.0.0"}}`))
+
+	// Release all handlers once every request is in flight at the same time.
+	go func() {
+		for range concurrency {
+			<-arrived
+		}
+		close(release)
+	}()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+	for i := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if i%2 == 0 {
+				_, _, err = client.Servers.List(context.Background(), nil)
+			} else {
+				_, _, err = client.Servers.Get(context.Background(), fmt.Sprintf("test/server-%d", i), nil)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("concurrent request returned error: %v", err)
+		}
+	}
+
+	if maxInFlight != concurrency {
+		t.Errorf("max requests in flight = %d, want %d", maxInFlight, concurrency)
+	}
+}
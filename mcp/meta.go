@@ -0,0 +1,156 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// HealthResponse represents the health status reported by the MCP Registry.
+type HealthResponse struct {
+	// Status is "ok" when the registry is healthy.
+	Status string `json:"status"`
+
+	// GitHubClientID is the client ID of the registry's GitHub OAuth App,
+	// used by publishers for the GitHub device flow.
+	GitHubClientID string `json:"github_client_id,omitempty"`
+}
+
+// PingResponse represents the response to a ping.
+type PingResponse struct {
+	Pong bool `json:"pong"`
+}
+
+// VersionResponse represents the build information of a registry instance.
+type VersionResponse struct {
+	// Version is the release version, such as "v1.2.3".
+	Version string `json:"version"`
+
+	// GitCommit is the commit the registry was built from.
+	GitCommit string `json:"git_commit"`
+
+	// BuildTime is the time the registry was built.
+	BuildTime string `json:"build_time"`
+}
+
+// ProbeResult reports the outcome of Client.Probe.
+type ProbeResult struct {
+	// Reachable reports whether the registry answered the health check.
+	Reachable bool
+
+	// Latency is the round-trip time of the health check.
+	Latency time.Duration
+
+	// Health is the health status reported by the registry, or nil if it was
+	// not reachable.
+	Health *HealthResponse
+
+	// Version is the build information reported by the registry, or nil if
+	// it was not reachable or does not expose the version endpoint.
+	Version *VersionResponse
+}
+
+// Health retrieves the health status of the MCP Registry.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-health
+func (s *MetaService) Health(ctx context.Context) (*HealthResponse, *Response, error) {
+	return s.health(ctx, false)
+}
+
+// health implements Health. If noCache is set, a response held in the
+// Client's Cache is revalidated with the API rather than served as is.
+func (s *MetaService) health(ctx context.Context, noCache bool) (*HealthResponse, *Response, error) {
+	var health *HealthResponse
+	resp, err := s.get(ctx, "v0.1/health", &health, noCache)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return health, resp, nil
+}
+
+// Ping checks that the MCP Registry is responding.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/ping
+func (s *MetaService) Ping(ctx context.Context) (*PingResponse, *Response, error) {
+	var ping *PingResponse
+	resp, err := s.get(ctx, "v0.1/ping", &ping, false)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ping, resp, nil
+}
+
+// Version retrieves the build information of the MCP Registry. Registries
+// that predate the version endpoint respond with a not found error.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-version
+func (s *MetaService) Version(ctx context.Context) (*VersionResponse, *Response, error) {
+	return s.version(ctx, false)
+}
+
+// version implements Version. If noCache is set, a response held in the
+// Client's Cache is revalidated with the API rather than served as is.
+func (s *MetaService) version(ctx context.Context, noCache bool) (*VersionResponse, *Response, error) {
+	var version *VersionResponse
+	resp, err := s.get(ctx, "v0.1/version", &version, noCache)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return version, resp, nil
+}
+
+func (s *MetaService) get(ctx context.Context, u string, v any, noCache bool) (*Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if noCache {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
+
+	return s.client.Do(ctx, req, v)
+}
+
+// Probe checks whether the registry at BaseURL is reachable, measuring the
+// latency of a health check and retrieving the registry's version.
+//
+// A registry that cannot be reached or reports an error from its health
+// check is returned as unreachable along with the error. A registry that
+// does not expose the version endpoint is reachable with a nil Version.
+// Responses held in the Client's Cache are revalidated rather than trusted,
+// so the result reflects the registry's current state.
+func (c *Client) Probe(ctx context.Context) (*ProbeResult, error) {
+	ctx, span := c.startSpan(ctx, "Client.Probe")
+	result, err := c.probe(ctx)
+	endSpan(span, err)
+
+	return result, err
+}
+
+// probe implements Probe.
+func (c *Client) probe(ctx context.Context) (*ProbeResult, error) {
+	result := &ProbeResult{}
+
+	start := time.Now()
+	health, _, err := c.Meta.health(ctx, true)
+	result.Latency = time.Since(start)
+	if err != nil {
+		return result, err
+	}
+	result.Reachable = true
+	result.Health = health
+
+	version, _, err := c.Meta.version(ctx, true)
+	if err != nil {
+		if IsNotFound(err) {
+			return result, nil
+		}
+		return result, err
+	}
+	result.Version = version
+
+	return result, nil
+}
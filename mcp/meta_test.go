@@ -0,0 +1,173 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMetaService_Health(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/health", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"status": "ok", "github_client_id": "Iv1.abc"}`)
+	})
+
+	health, _, err := client.Meta.Health(context.Background())
+	if err != nil {
+		t.Fatalf("Meta.Health returned error: %v", err)
+	}
+
+	want := &HealthResponse{Status: "ok", GitHubClientID: "Iv1.abc"}
+	if !reflect.DeepEqual(health, want) {
+		t.Errorf("Meta.Health returned %+v, want %+v", health, want)
+	}
+}
+
+func TestMetaService_Ping(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	ping, _, err := client.Meta.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Meta.Ping returned error: %v", err)
+	}
+	if !ping.Pong {
+		t.Errorf("Meta.Ping returned %+v, want pong", ping)
+	}
+}
+
+func TestMetaService_Version(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/version", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"version": "v1.2.3", "git_commit": "abc1234", "build_time": "2024-01-01T00:00:00Z"}`)
+	})
+
+	version, _, err := client.Meta.Version(context.Background())
+	if err != nil {
+		t.Fatalf("Meta.Version returned error: %v", err)
+	}
+
+	want := &VersionResponse{Version: "v1.2.3", GitCommit: "abc1234", BuildTime: "2024-01-01T00:00:00Z"}
+	if !reflect.DeepEqual(version, want) {
+		t.Errorf("Meta.Version returned %+v, want %+v", version, want)
+	}
+}
+
+func TestClient_Probe(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/health", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprint(w, `{"status": "ok"}`)
+	})
+	mux.HandleFunc("/v0.1/version", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"version": "v1.2.3", "git_commit": "abc1234", "build_time": "2024-01-01T00:00:00Z"}`)
+	})
+
+	result, err := client.Probe(context.Background())
+	if err != nil {
+		t.Fatalf("Probe returned error: %v", err)
+	}
+
+	if !result.Reachable {
+		t.Error("Probe Reachable = false, want true")
+	}
+	if result.Latency < 10*time.Millisecond {
+		t.Errorf("Probe Latency = %v, want at least 10ms", result.Latency)
+	}
+	if result.Health == nil || result.Health.Status != "ok" {
+		t.Errorf("Probe Health = %+v, want status ok", result.Health)
+	}
+	if result.Version == nil || result.Version.Version != "v1.2.3" {
+		t.Errorf("Probe Version = %+v, want v1.2.3", result.Version)
+	}
+}
+
+func TestClient_Probe_BypassesCache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+
+	var healthChecks, versionChecks int
+	mux.HandleFunc("/v0.1/health", func(w http.ResponseWriter, r *http.Request) {
+		healthChecks++
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, `{"status": "ok"}`)
+	})
+	mux.HandleFunc("/v0.1/version", func(w http.ResponseWriter, r *http.Request) {
+		versionChecks++
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, `{"version": "v1.2.3"}`)
+	})
+
+	for range 2 {
+		if _, err := client.Probe(context.Background()); err != nil {
+			t.Fatalf("Probe returned error: %v", err)
+		}
+	}
+	if healthChecks != 2 || versionChecks != 2 {
+		t.Errorf("registry received %d health and %d version requests, want 2 and 2", healthChecks, versionChecks)
+	}
+}
+
+func TestClient_Probe_NoVersionEndpoint(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/health", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "ok"}`)
+	})
+
+	result, err := client.Probe(context.Background())
+	if err != nil {
+		t.Fatalf("Probe returned error: %v", err)
+	}
+	if !result.Reachable {
+		t.Error("Probe Reachable = false, want true")
+	}
+	if result.Version != nil {
+		t.Errorf("Probe Version = %+v, want nil", result.Version)
+	}
+}
+
+func TestClient_Probe_Unreachable(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+
+	mux.HandleFunc("/v0.1/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"title": "Service Unavailable", "status": 503, "detail": "database unavailable"}`)
+	})
+
+	result, err := client.Probe(context.Background())
+	if !IsServerUnavailable(err) {
+		t.Errorf("Probe error = %v, want IsServerUnavailable", err)
+	}
+	if result.Reachable || result.Health != nil {
+		t.Errorf("Probe result = %+v, want unreachable", result)
+	}
+
+	teardown()
+
+	result, err = client.Probe(context.Background())
+	if err == nil {
+		t.Fatalf("Probe of closed server %s expected error, got nil", serverURL)
+	}
+	if result.Reachable {
+		t.Error("Probe of closed server Reachable = true, want false")
+	}
+}
@@ -0,0 +1,201 @@
+package mcp
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of the requests made by a Client, so that
+// they can be exported to a monitoring system such as Prometheus without the
+// SDK depending on it. Implementations must be safe for concurrent use.
+//
+// Requests are identified by their route template, such as
+// "v0.1/servers/{name}/versions/{version}", rather than their URL, so that
+// the number of distinct series stays small.
+type Metrics interface {
+	// RequestStarted is called when Do starts handling a request.
+	RequestStarted(method, route string)
+
+	// RequestFinished is called once Do has finished handling a request,
+	// including any retries and the decoding of the response body.
+	RequestFinished(m RequestMetrics)
+}
+
+// RequestMetrics describes a request handled by Client.Do.
+type RequestMetrics struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Route is the route template of the request, such as
+	// "v0.1/servers/{name}/versions".
+	Route string
+
+	// StatusClass is the class of the response status code: "2xx", "3xx",
+	// "4xx" or "5xx", or "error" if no response was received.
+	StatusClass string
+
+	// Duration is the time taken by Do, including retries and decoding.
+	Duration time.Duration
+
+	// BytesSent is the size of the request body.
+	BytesSent int64
+
+	// BytesReceived is the size of the response body.
+	BytesReceived int64
+
+	// Retries is the number of attempts made after the first one.
+	Retries int
+
+	// FromCache reports whether the response was served from the Client's
+	// Cache.
+	FromCache bool
+
+	// Rate is the rate limit reported with the response. Its Limit is zero
+	// if the response carried no rate limit headers.
+	Rate Rate
+}
+
+// recordRequest reports a request handled by Do to the Client's Metrics.
+// attempts is the number of attempts made to send it, whether or not a
+// response was received.
+func (c *Client) recordRequest(req *http.Request, start time.Time, resp *Response, attempts int, received int64, err error) {
+	if c.Metrics == nil {
+		return
+	}
+
+	m := RequestMetrics{
+		Method:        req.Method,
+		Route:         c.route(req),
+		StatusClass:   "error",
+		Duration:      time.Since(start),
+		BytesSent:     max(req.ContentLength, 0),
+		BytesReceived: received,
+		Retries:       max(attempts-1, 0),
+	}
+	if resp != nil && resp.Response != nil {
+		m.StatusClass = fmt.Sprintf("%dxx", resp.StatusCode/100)
+		m.FromCache = resp.FromCache
+		m.Rate = resp.Rate
+		if err != nil && received == 0 {
+			// The body of an API error was consumed by CheckResponse
+			m.BytesReceived = max(resp.ContentLength, 0)
+		}
+	}
+
+	c.Metrics.RequestFinished(m)
+}
+
+// route returns the route template of req: its path relative to the
+// BaseURL, with server names and versions replaced by placeholders.
+func (c *Client) route(req *http.Request) string {
+	path := req.URL.EscapedPath()
+	if c.BaseURL != nil {
+		path = strings.TrimPrefix(path, c.BaseURL.EscapedPath())
+	}
+	path = strings.TrimPrefix(path, "/")
+
+	// v0.1/servers/{name}/versions/{version}
+	segments := strings.Split(path, "/")
+	if len(segments) > 2 && segments[1] == "servers" {
+		segments[2] = "{name}"
+		if len(segments) > 4 && segments[3] == "versions" {
+			segments[4] = "{version}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// ExpvarMetrics is a Metrics implementation publishing request metrics with
+// the expvar package, where they are served as JSON at /debug/vars.
+//
+// Metrics are published as an expvar.Map holding one map per measurement,
+// keyed by "METHOD route" and, for requests, the status class:
+//
+//	requests               number of requests, keyed "GET v0.1/servers 2xx"
+//	in_flight              number of requests in progress
+//	duration_seconds       total duration of finished requests
+//	bytes_sent             total size of request bodies
+//	bytes_received         total size of response bodies
+//	retries                total number of retried attempts
+//	cache_hits             number of responses served from the cache
+//	rate_limit_remaining   requests remaining in the last rate limit window
+type ExpvarMetrics struct {
+	requests           *expvar.Map
+	inFlight           *expvar.Map
+	duration           *expvar.Map
+	bytesSent          *expvar.Map
+	bytesReceived      *expvar.Map
+	retries            *expvar.Map
+	cacheHits          *expvar.Map
+	rateLimitRemaining *expvar.Map
+}
+
+// NewExpvarMetrics returns an ExpvarMetrics publishing its metrics under
+// name. Like expvar.Publish, it panics if name is already in use.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{
+		requests:           new(expvar.Map),
+		inFlight:           new(expvar.Map),
+		duration:           new(expvar.Map),
+		bytesSent:          new(expvar.Map),
+		bytesReceived:      new(expvar.Map),
+		retries:            new(expvar.Map),
+		cacheHits:          new(expvar.Map),
+		rateLimitRemaining: new(expvar.Map),
+	}
+
+	root := expvar.NewMap(name)
+	root.Set("requests", m.requests)
+	root.Set("in_flight", m.inFlight)
+	root.Set("duration_seconds", m.duration)
+	root.Set("bytes_sent", m.bytesSent)
+	root.Set("bytes_received", m.bytesReceived)
+	root.Set("retries", m.retries)
+	root.Set("cache_hits", m.cacheHits)
+	root.Set("rate_limit_remaining", m.rateLimitRemaining)
+
+	return m
+}
+
+// RequestStarted counts a request in flight.
+func (m *ExpvarMetrics) RequestStarted(method, route string) {
+	m.inFlight.Add(method+" "+route, 1)
+}
+
+// RequestFinished records a finished request.
+func (m *ExpvarMetrics) RequestFinished(r RequestMetrics) {
+	key := r.Method + " " + r.Route
+
+	m.inFlight.Add(key, -1)
+	m.requests.Add(key+" "+r.StatusClass, 1)
+	m.duration.AddFloat(key, r.Duration.Seconds())
+	m.bytesSent.Add(key, r.BytesSent)
+	m.bytesReceived.Add(key, r.BytesReceived)
+	if r.Retries > 0 {
+		m.retries.Add(key, int64(r.Retries))
+	}
+	if r.FromCache {
+		m.cacheHits.Add(key, 1)
+	}
+	if r.Rate.Limit > 0 {
+		remaining := new(expvar.Int)
+		remaining.Set(int64(r.Rate.Remaining))
+		m.rateLimitRemaining.Set(key, remaining)
+	}
+}
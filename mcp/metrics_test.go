@@ -0,0 +1,309 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// fakeMetrics records the measurements it receives.
+type fakeMetrics struct {
+	mu       sync.Mutex
+	started  []string
+	finished []RequestMetrics
+}
+
+func (m *fakeMetrics) RequestStarted(method, route string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started = append(m.started, method+" "+route)
+}
+
+func (m *fakeMetrics) RequestFinished(r RequestMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.finished = append(m.finished, r)
+}
+
+func TestClient_route(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		path    string
+		want    string
+	}{
+		{"https://registry.example.com/", "v0.1/servers?search=github", "v0.1/servers"},
+		{"https://registry.example.com/", "v0.1/servers/io.github.user%2Fserver/versions", "v0.1/servers/{name}/versions"},
+		{"https://registry.example.com/", "v0.1/servers/io.github.user%2Fserver/versions/1.0.0", "v0.1/servers/{name}/versions/{version}"},
+		{"https://registry.example.com/", "v0.1/servers/io.github.user%2Fserver/versions/latest", "v0.1/servers/{name}/versions/{version}"},
+		{"https://registry.example.com/", "v0.1/publish", "v0.1/publish"},
+		{"https://registry.example.com/", "v0.1/auth/github-at", "v0.1/auth/github-at"},
+		{"https://example.com/registry/", "v0.1/servers/a%2Fb/versions", "v0.1/servers/{name}/versions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			client := NewClient(nil)
+			client.BaseURL, _ = url.Parse(tt.baseURL)
+
+			req, err := client.NewRequest(http.MethodGet, tt.path, nil)
+			if err != nil {
+				t.Fatalf("NewRequest returned error: %v", err)
+			}
+			if got := client.route(req); got != tt.want {
+				t.Errorf("route(%s) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Metrics(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	metrics := &fakeMetrics{}
+	client.Metrics = metrics
+
+	const body = `{"server": {"name": "test/server", "version": "1.0.0"}}`
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		fmt.Fprint(w, body)
+	})
+
+	if _, _, err := client.Servers.GetByNameExactVersion(context.Background(), "test/server", "1.0.0"); err != nil {
+		t.Fatalf("GetByNameExactVersion returned error: %v", err)
+	}
+
+	const route = "v0.1/servers/{name}/versions/{version}"
+	if want := []string{"GET " + route}; fmt.Sprint(metrics.started) != fmt.Sprint(want) {
+		t.Errorf("started = %v, want %v", metrics.started, want)
+	}
+	if len(metrics.finished) != 1 {
+		t.Fatalf("finished %d requests, want 1", len(metrics.finished))
+	}
+
+	got := metrics.finished[0]
+	if got.Duration <= 0 {
+		t.Errorf("Duration = %v, want positive", got.Duration)
+	}
+	got.Duration = 0
+	want := RequestMetrics{
+		Method:        "GET",
+		Route:         route,
+		StatusClass:   "2xx",
+		BytesReceived: int64(len(body)),
+		Rate:          Rate{Limit: 100, Remaining: 99},
+	}
+	if got != want {
+		t.Errorf("finished = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_Metrics_Outcomes(t *testing.T) {
+	const notFound = `{"title": "Not Found", "status": 404}`
+
+	tests := []struct {
+		name  string
+		setup func(client *Client, mux *http.ServeMux)
+		call  func(client *Client) error
+		check func(t *testing.T, finished []RequestMetrics)
+	}{
+		{
+			name: "request body",
+			setup: func(client *Client, mux *http.ServeMux) {
+				client.SetAuthToken("token")
+				mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, `{"server": {"name": "test/server", "version": "1.0.0"}}`)
+				})
+			},
+			call: func(client *Client) error {
+				_, _, err := client.Servers.Publish(context.Background(), &registryv0.ServerJSON{Name: "test/server", Version: "1.0.0"})
+				return err
+			},
+			check: func(t *testing.T, finished []RequestMetrics) {
+				if finished[0].Route != "v0.1/publish" || finished[0].BytesSent == 0 {
+					t.Errorf("finished = %+v, want v0.1/publish with bytes sent", finished[0])
+				}
+			},
+		},
+		{
+			name: "api error",
+			setup: func(client *Client, mux *http.ServeMux) {
+				mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, notFound)
+				})
+			},
+			call: func(client *Client) error {
+				_, _, err := client.Meta.Ping(context.Background())
+				return err
+			},
+			check: func(t *testing.T, finished []RequestMetrics) {
+				if finished[0].StatusClass != "4xx" || finished[0].BytesReceived != int64(len(notFound)) {
+					t.Errorf("finished = %+v, want 4xx with %d bytes received", finished[0], len(notFound))
+				}
+			},
+		},
+		{
+			name: "retries",
+			setup: func(client *Client, mux *http.ServeMux) {
+				client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+				attempts := 0
+				mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+					if attempts++; attempts < 3 {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					fmt.Fprint(w, `{"pong": true}`)
+				})
+			},
+			call: func(client *Client) error {
+				_, _, err := client.Meta.Ping(context.Background())
+				return err
+			},
+			check: func(t *testing.T, finished []RequestMetrics) {
+				if finished[0].StatusClass != "2xx" || finished[0].Retries != 2 {
+					t.Errorf("finished = %+v, want 2xx after 2 retries", finished[0])
+				}
+			},
+		},
+		{
+			name: "cache hit",
+			setup: func(client *Client, mux *http.ServeMux) {
+				client.Cache = NewMemoryCache(10)
+				mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Cache-Control", "max-age=60")
+					fmt.Fprint(w, `{"pong": true}`)
+				})
+			},
+			call: func(client *Client) error {
+				client.Meta.Ping(context.Background())
+				_, _, err := client.Meta.Ping(context.Background())
+				return err
+			},
+			check: func(t *testing.T, finished []RequestMetrics) {
+				if len(finished) != 2 || finished[0].FromCache || !finished[1].FromCache {
+					t.Errorf("finished = %+v, want a miss then a hit", finished)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			metrics := &fakeMetrics{}
+			client.Metrics = metrics
+
+			tt.setup(client, mux)
+			tt.call(client)
+
+			if len(metrics.finished) == 0 {
+				t.Fatal("no requests finished")
+			}
+			if len(metrics.started) != len(metrics.finished) {
+				t.Errorf("started %d requests, finished %d", len(metrics.started), len(metrics.finished))
+			}
+			tt.check(t, metrics.finished)
+		})
+	}
+}
+
+func TestClient_Metrics_TransportError(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      *RetryPolicy
+		wantRetries int
+	}{
+		{name: "no retries", policy: nil, wantRetries: 0},
+		{name: "retries exhausted", policy: &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}, wantRetries: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				attempts++
+				return nil, errors.New("connection refused")
+			})
+			client, err := New(WithTransport(transport))
+			if err != nil {
+				t.Fatalf("New returned error: %v", err)
+			}
+			client.RetryPolicy = tt.policy
+			metrics := &fakeMetrics{}
+			client.Metrics = metrics
+
+			if _, _, err := client.Meta.Ping(context.Background()); err == nil {
+				t.Fatal("Meta.Ping expected error, got nil")
+			}
+			if attempts != tt.wantRetries+1 {
+				t.Errorf("transport received %d attempts, want %d", attempts, tt.wantRetries+1)
+			}
+			if len(metrics.finished) != 1 {
+				t.Fatalf("finished %d requests, want 1", len(metrics.finished))
+			}
+			if got := metrics.finished[0]; got.StatusClass != "error" || got.Retries != tt.wantRetries {
+				t.Errorf("finished = %+v, want error with %d retries", got, tt.wantRetries)
+			}
+		})
+	}
+}
+
+// expvarRuns numbers the runs of TestExpvarMetrics, since expvar names can
+// only be published once per process.
+var expvarRuns atomic.Int32
+
+func TestExpvarMetrics(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	name := fmt.Sprintf("%s_%d", t.Name(), expvarRuns.Add(1))
+	client.Metrics = NewExpvarMetrics(name)
+
+	mux.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "98")
+		fmt.Fprint(w, `{"server": {"name": "test/server", "version": "1.0.0"}}`)
+	})
+
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		if _, _, err := client.Servers.GetByNameExactVersion(context.Background(), "test/server", version); err != nil {
+			t.Fatalf("GetByNameExactVersion returned error: %v", err)
+		}
+	}
+
+	var published map[string]map[string]float64
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &published); err != nil {
+		t.Fatalf("decoding published metrics: %v", err)
+	}
+
+	const key = "GET v0.1/servers/{name}/versions/{version}"
+	checks := []struct {
+		metric, key string
+		want        float64
+	}{
+		{"requests", key + " 2xx", 2},
+		{"in_flight", key, 0},
+		{"bytes_received", key, 2 * float64(len(`{"server": {"name": "test/server", "version": "1.0.0"}}`))},
+		{"bytes_sent", key, 0},
+		{"rate_limit_remaining", key, 98},
+	}
+	for _, c := range checks {
+		if got := published[c.metric][c.key]; got != c.want {
+			t.Errorf("%s[%q] = %v, want %v", c.metric, c.key, got, c.want)
+		}
+	}
+	if published["duration_seconds"][key] <= 0 {
+		t.Errorf("duration_seconds[%q] = %v, want positive", key, published["duration_seconds"][key])
+	}
+}
@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"net/http"
+)
+
+// RoundTripFunc sends a single attempt of an API request and returns its
+// response. As with Client.Do, API errors are returned as an error alongside
+// the Response they were parsed from.
+type RoundTripFunc func(req *http.Request) (*Response, error)
+
+// Middleware wraps the sending of API requests. It receives the next
+// RoundTripFunc in the chain and returns one that may inspect or modify the
+// request before calling next, inspect the Response and error it returns, or
+// answer the request itself without calling next at all.
+//
+// Middleware runs around every attempt Do sends, so a request that is
+// retried passes through it once per attempt. Responses served from the
+// Client's Cache without contacting the API bypass it. A Middleware that
+// modifies the request should pass a clone of it, made with
+// http.Request.Clone, to next.
+//
+//	func tenantHeader(tenant string) mcp.Middleware {
+//		return func(next mcp.RoundTripFunc) mcp.RoundTripFunc {
+//			return func(req *http.Request) (*mcp.Response, error) {
+//				req = req.Clone(req.Context())
+//				req.Header.Set("X-Tenant", tenant)
+//				return next(req)
+//			}
+//		}
+//	}
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use appends middleware to the chain run around every request the Client
+// sends. Middleware registered first runs outermost: it sees the request
+// before, and the response after, middleware registered later.
+//
+// Use is not safe to call concurrently with requests; register middleware
+// while configuring the Client.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// send sends a single attempt of an API request through the Client's
+// middleware chain.
+func (c *Client) send(req *http.Request) (*Response, error) {
+	next := func(req *http.Request) (*Response, error) {
+		return c.bareDo(req.Context(), req)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+	return next(req)
+}
@@ -0,0 +1,195 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingMiddleware appends to calls when it sees the request and the
+// response.
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*Response, error) {
+			*calls = append(*calls, name+" request")
+			resp, err := next(req)
+			*calls = append(*calls, name+" response")
+			return resp, err
+		}
+	}
+}
+
+func TestClient_Use_Order(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls []string
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "registry")
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	client.Use(recordingMiddleware("first", &calls), recordingMiddleware("second", &calls))
+	client.Use(recordingMiddleware("third", &calls))
+
+	if _, _, err := client.Meta.Ping(context.Background()); err != nil {
+		t.Fatalf("Meta.Ping returned error: %v", err)
+	}
+
+	want := []string{
+		"first request",
+		"second request",
+		"third request",
+		"registry",
+		"third response",
+		"second response",
+		"first response",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestClient_Use_ModifiesRequest(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("X-Tenant header = %q, want %q", got, "acme")
+		}
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	client.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Tenant", "acme")
+			return next(req)
+		}
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "v0.1/ping", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if req.Header.Get("X-Tenant") != "" {
+		t.Error("middleware modified the caller's request")
+	}
+}
+
+func TestClient_Use_InspectsResponse(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404}`)
+	})
+
+	var gotRemaining int
+	var gotErr error
+	client.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*Response, error) {
+			resp, err := next(req)
+			gotRemaining, gotErr = resp.Rate.Remaining, err
+			return resp, err
+		}
+	})
+
+	_, _, err := client.Servers.Get(context.Background(), "test/server", nil)
+	if !IsNotFound(err) {
+		t.Fatalf("Servers.Get error = %v, want IsNotFound", err)
+	}
+	if gotRemaining != 42 {
+		t.Errorf("middleware saw Rate.Remaining = %d, want 42", gotRemaining)
+	}
+	if !IsNotFound(gotErr) {
+		t.Errorf("middleware saw error %v, want IsNotFound", gotErr)
+	}
+}
+
+func TestClient_Use_FakeFailure(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+
+	var hits int
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	// Fail the first attempt without sending it to the registry
+	attempts := 0
+	client.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*Response, error) {
+			attempts++
+			if attempts > 1 {
+				return next(req)
+			}
+			resp := &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"title": "Service Unavailable", "status": 503}`)),
+				Request:    req,
+			}
+			return newResponse(resp), CheckResponse(resp)
+		}
+	})
+
+	ping, _, err := client.Meta.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Meta.Ping returned error: %v", err)
+	}
+	if !ping.Pong {
+		t.Errorf("Meta.Ping returned %+v, want pong", ping)
+	}
+	if attempts != 2 || hits != 1 {
+		t.Errorf("attempts = %d and registry hits = %d, want 2 and 1", attempts, hits)
+	}
+}
+
+func TestClient_Use_CacheHitBypassesMiddleware(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	var calls []string
+	client.Use(recordingMiddleware("mw", &calls))
+
+	for range 2 {
+		if _, _, err := client.Meta.Ping(context.Background()); err != nil {
+			t.Fatalf("Meta.Ping returned error: %v", err)
+		}
+	}
+	if len(calls) != 2 {
+		t.Errorf("calls = %v, want a single request and response", calls)
+	}
+}
+
+func TestWithMiddleware(t *testing.T) {
+	var calls []string
+	client, err := New(WithMiddleware(recordingMiddleware("a", &calls), recordingMiddleware("b", &calls)))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if len(client.middleware) != 2 {
+		t.Errorf("registered %d middleware, want 2", len(client.middleware))
+	}
+}
@@ -0,0 +1,284 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ClientOption configures a Client created with New.
+type ClientOption func(*Client) error
+
+// New returns a new MCP Registry API client configured with the provided
+// options. Options are applied in order and validated eagerly; the first
+// invalid option causes New to return an error.
+//
+// Without options, New returns a client equivalent to NewClient(nil).
+func New(opts ...ClientOption) (*Client, error) {
+	c := NewClient(nil)
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// WithBaseURL sets the base URL for API requests. The URL must be absolute.
+// A trailing slash is appended to its path if missing.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		u, err := parseBaseURL(baseURL)
+		if err != nil {
+			return err
+		}
+		c.BaseURL = u
+		return nil
+	}
+}
+
+// parseBaseURL parses and validates a registry base URL, ensuring its path
+// has a trailing slash.
+func parseBaseURL(baseURL string) (*url.URL, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: must be absolute", baseURL)
+	}
+
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	return u, nil
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) error {
+		if userAgent == "" {
+			return errors.New("user agent must not be empty")
+		}
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithHTTPClient sets the http.Client used to communicate with the API,
+// replacing any timeout or transport configured by earlier options.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("http client must not be nil")
+		}
+		c.client = httpClient
+		return nil
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to send requests. The
+// http.Client in use is copied rather than modified.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		if transport == nil {
+			return errors.New("transport must not be nil")
+		}
+		hc := *c.client
+		hc.Transport = transport
+		c.client = &hc
+		return nil
+	}
+}
+
+// WithTimeout sets the overall timeout for each HTTP request. A zero timeout
+// means no timeout. The http.Client in use is copied rather than modified.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout must not be negative, got %v", timeout)
+		}
+		hc := *c.client
+		hc.Timeout = timeout
+		c.client = &hc
+		return nil
+	}
+}
+
+// WithAuthToken sets the registry JWT sent as a bearer token with write
+// requests.
+func WithAuthToken(token string) ClientOption {
+	return func(c *Client) error {
+		if token == "" {
+			return errors.New("auth token must not be empty")
+		}
+		c.SetAuthToken(token)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed idempotent requests.
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy == nil {
+			return errors.New("retry policy must not be nil")
+		}
+		if policy.MaxAttempts < 0 {
+			return fmt.Errorf("retry policy MaxAttempts must not be negative, got %d", policy.MaxAttempts)
+		}
+		if policy.MinBackoff < 0 || policy.MaxBackoff < 0 {
+			return errors.New("retry policy backoff must not be negative")
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			return fmt.Errorf("retry policy Jitter must be between 0 and 1, got %v", policy.Jitter)
+		}
+		c.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithWaitForRateLimit makes requests block until the rate limit window
+// resets when the API reports no remaining requests.
+func WithWaitForRateLimit() ClientOption {
+	return func(c *Client) error {
+		c.WaitForRateLimit = true
+		return nil
+	}
+}
+
+// WithLogger logs requests made by the client to logger. Successful requests
+// are logged at debug level, failed ones at info or warn level.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		if logger == nil {
+			return errors.New("logger must not be nil")
+		}
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithMetrics reports measurements of each request to metrics.
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(c *Client) error {
+		if metrics == nil {
+			return errors.New("metrics must not be nil")
+		}
+		c.Metrics = metrics
+		return nil
+	}
+}
+
+// WithTracer starts spans for the client's requests and operations with
+// tracer.
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) error {
+		if tracer == nil {
+			return errors.New("tracer must not be nil")
+		}
+		c.Tracer = tracer
+		return nil
+	}
+}
+
+// WithEndpoints sets the registry base URLs that read requests fail over
+// between, in order of preference. The first one becomes the BaseURL, to
+// which writes are sent. See Client.Endpoints.
+func WithEndpoints(baseURLs ...string) ClientOption {
+	return func(c *Client) error {
+		if len(baseURLs) == 0 {
+			return errors.New("at least one endpoint is required")
+		}
+
+		endpoints := make([]*url.URL, len(baseURLs))
+		for i, baseURL := range baseURLs {
+			u, err := parseBaseURL(baseURL)
+			if err != nil {
+				return err
+			}
+			endpoints[i] = u
+		}
+		c.BaseURL = endpoints[0]
+		c.Endpoints = endpoints
+		return nil
+	}
+}
+
+// WithEndpointCooldown sets how long a failed endpoint is skipped.
+func WithEndpointCooldown(cooldown time.Duration) ClientOption {
+	return func(c *Client) error {
+		if cooldown <= 0 {
+			return fmt.Errorf("endpoint cooldown must be positive, got %v", cooldown)
+		}
+		c.EndpointCooldown = cooldown
+		return nil
+	}
+}
+
+// WithMiddleware registers middleware run around every request the client
+// sends, in the order given. See Client.Use.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) error {
+		for _, mw := range middleware {
+			if mw == nil {
+				return errors.New("middleware must not be nil")
+			}
+		}
+		c.Use(middleware...)
+		return nil
+	}
+}
+
+// WithRequestCoalescing makes identical GET requests issued concurrently
+// share a single round-trip.
+func WithRequestCoalescing() ClientOption {
+	return func(c *Client) error {
+		c.CoalesceRequests = true
+		return nil
+	}
+}
+
+// WithRateLimiter throttles outgoing requests client-side to
+// requestsPerSecond on average, with bursts of up to burst requests.
+func WithRateLimiter(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) error {
+		if requestsPerSecond <= 0 {
+			return fmt.Errorf("requests per second must be positive, got %v", requestsPerSecond)
+		}
+		if burst < 1 {
+			return fmt.Errorf("burst must be at least 1, got %d", burst)
+		}
+		c.RateLimiter = NewTokenBucket(requestsPerSecond, burst)
+		return nil
+	}
+}
+
+// WithVersionComparator sets the strategy used to order versions of a server.
+func WithVersionComparator(cmp VersionComparator) ClientOption {
+	return func(c *Client) error {
+		if cmp == nil {
+			return errors.New("version comparator must not be nil")
+		}
+		c.VersionComparator = cmp
+		return nil
+	}
+}
+
+// WithCache stores GET responses in cache, serving them while fresh and
+// revalidating them with the API once stale.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) error {
+		if cache == nil {
+			return errors.New("cache must not be nil")
+		}
+		c.Cache = cache
+		return nil
+	}
+}
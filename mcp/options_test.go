@@ -0,0 +1,240 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNew_Defaults(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	if got := c.BaseURL.String(); got != defaultBaseURL {
+		t.Errorf("New() BaseURL = %q, want %q", got, defaultBaseURL)
+	}
+	if c.UserAgent != userAgent {
+		t.Errorf("New() UserAgent = %q, want %q", c.UserAgent, userAgent)
+	}
+	if c.Servers == nil {
+		t.Error("New() Servers service not initialized")
+	}
+	if c.RetryPolicy != nil {
+		t.Errorf("New() RetryPolicy = %+v, want nil", c.RetryPolicy)
+	}
+}
+
+func TestNew_Options(t *testing.T) {
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	policy := DefaultRetryPolicy()
+
+	c, err := New(
+		WithBaseURL("https://registry.example.com/api"),
+		WithUserAgent("my-app/1.0"),
+		WithHTTPClient(httpClient),
+		WithTimeout(10*time.Second),
+		WithRetryPolicy(policy),
+		WithWaitForRateLimit(),
+		WithRequestCoalescing(),
+		WithRateLimiter(5, 10),
+		WithCache(NewMemoryCache(10)),
+	)
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	if got, want := c.BaseURL.String(), "https://registry.example.com/api/"; got != want {
+		t.Errorf("BaseURL = %q, want %q", got, want)
+	}
+	if c.UserAgent != "my-app/1.0" {
+		t.Errorf("UserAgent = %q, want %q", c.UserAgent, "my-app/1.0")
+	}
+	if c.client.Timeout != 10*time.Second {
+		t.Errorf("http client Timeout = %v, want %v", c.client.Timeout, 10*time.Second)
+	}
+	if httpClient.Timeout != 5*time.Second {
+		t.Error("WithTimeout() modified the caller's http.Client")
+	}
+	if c.RetryPolicy != policy {
+		t.Errorf("RetryPolicy = %+v, want %+v", c.RetryPolicy, policy)
+	}
+	if !c.WaitForRateLimit {
+		t.Error("WaitForRateLimit = false, want true")
+	}
+	if !c.CoalesceRequests {
+		t.Error("CoalesceRequests = false, want true")
+	}
+	if c.RateLimiter == nil {
+		t.Error("RateLimiter = nil, want token bucket")
+	}
+	if c.Cache == nil {
+		t.Error("Cache = nil, want memory cache")
+	}
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		opt        ClientOption
+		wantErrMsg string
+	}{
+		{
+			name:       "relative base URL",
+			opt:        WithBaseURL("registry.example.com"),
+			wantErrMsg: "must be absolute",
+		},
+		{
+			name:       "unparseable base URL",
+			opt:        WithBaseURL("://invalid"),
+			wantErrMsg: "invalid base URL",
+		},
+		{
+			name:       "empty user agent",
+			opt:        WithUserAgent(""),
+			wantErrMsg: "user agent must not be empty",
+		},
+		{
+			name:       "nil http client",
+			opt:        WithHTTPClient(nil),
+			wantErrMsg: "http client must not be nil",
+		},
+		{
+			name:       "nil transport",
+			opt:        WithTransport(nil),
+			wantErrMsg: "transport must not be nil",
+		},
+		{
+			name:       "negative timeout",
+			opt:        WithTimeout(-time.Second),
+			wantErrMsg: "timeout must not be negative",
+		},
+		{
+			name:       "empty auth token",
+			opt:        WithAuthToken(""),
+			wantErrMsg: "auth token must not be empty",
+		},
+		{
+			name:       "nil retry policy",
+			opt:        WithRetryPolicy(nil),
+			wantErrMsg: "retry policy must not be nil",
+		},
+		{
+			name:       "retry policy with invalid jitter",
+			opt:        WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, Jitter: 2}),
+			wantErrMsg: "Jitter must be between 0 and 1",
+		},
+		{
+			name:       "retry policy with negative backoff",
+			opt:        WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, MinBackoff: -time.Second}),
+			wantErrMsg: "backoff must not be negative",
+		},
+		{
+			name:       "zero rate limiter rate",
+			opt:        WithRateLimiter(0, 1),
+			wantErrMsg: "requests per second must be positive",
+		},
+		{
+			name:       "zero rate limiter burst",
+			opt:        WithRateLimiter(1, 0),
+			wantErrMsg: "burst must be at least 1",
+		},
+		{
+			name:       "nil version comparator",
+			opt:        WithVersionComparator(nil),
+			wantErrMsg: "version comparator must not be nil",
+		},
+		{
+			name:       "nil cache",
+			opt:        WithCache(nil),
+			wantErrMsg: "cache must not be nil",
+		},
+		{
+			name:       "nil logger",
+			opt:        WithLogger(nil),
+			wantErrMsg: "logger must not be nil",
+		},
+		{
+			name:       "nil middleware",
+			opt:        WithMiddleware(nil),
+			wantErrMsg: "middleware must not be nil",
+		},
+		{
+			name:       "nil metrics",
+			opt:        WithMetrics(nil),
+			wantErrMsg: "metrics must not be nil",
+		},
+		{
+			name:       "nil tracer",
+			opt:        WithTracer(nil),
+			wantErrMsg: "tracer must not be nil",
+		},
+		{
+			name:       "no endpoints",
+			opt:        WithEndpoints(),
+			wantErrMsg: "at least one endpoint is required",
+		},
+		{
+			name:       "relative endpoint",
+			opt:        WithEndpoints("https://registry.example.com", "/mirror"),
+			wantErrMsg: `invalid base URL "/mirror": must be absolute`,
+		},
+		{
+			name:       "zero endpoint cooldown",
+			opt:        WithEndpointCooldown(0),
+			wantErrMsg: "endpoint cooldown must be positive, got 0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(tt.opt)
+			if err == nil {
+				t.Fatal("New() expected error, got nil")
+			}
+			if c != nil {
+				t.Errorf("New() returned client %v alongside error", c)
+			}
+			if !strings.Contains(err.Error(), tt.wantErrMsg) {
+				t.Errorf("New() error = %q, want to contain %q", err.Error(), tt.wantErrMsg)
+			}
+		})
+	}
+}
+
+func TestNew_WithTransport(t *testing.T) {
+	var gotUserAgent string
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		rec := httptest.NewRecorder()
+		fmt.Fprint(rec, `{"servers": [], "metadata": {}}`)
+		return rec.Result(), nil
+	})
+
+	c, err := New(
+		WithBaseURL("https://registry.example.com"),
+		WithUserAgent("transport-test"),
+		WithTransport(transport),
+	)
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	if _, _, err := c.Servers.List(context.Background(), nil); err != nil {
+		t.Fatalf("Servers.List returned error: %v", err)
+	}
+	if gotUserAgent != "transport-test" {
+		t.Errorf("User-Agent = %q, want %q", gotUserAgent, "transport-test")
+	}
+}
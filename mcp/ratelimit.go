@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TokenBucket is a client-side rate limiter that allows requests at a steady
+// rate with bursts of up to a fixed size. It is safe for concurrent use.
+//
+// Assign a TokenBucket to Client.RateLimiter to throttle outgoing requests
+// independently of the rate limits reported by the API.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // maximum number of tokens
+	tokens float64 // tokens currently available
+	last   time.Time
+}
+
+// NewTokenBucket returns a TokenBucket that permits requestsPerSecond requests
+// on average with bursts of up to burst requests. The bucket starts full. A
+// burst below 1 is treated as 1.
+func NewTokenBucket(requestsPerSecond float64, burst int) *TokenBucket {
+	b := float64(max(burst, 1))
+	return &TokenBucket{
+		rate:   requestsPerSecond,
+		burst:  b,
+		tokens: b,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent, or until ctx is done, in which
+// case ctx.Err() is returned.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := b.reserve(time.Now())
+		if delay == 0 {
+			return nil
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if one is available at now and returns zero.
+// Otherwise it returns how long to wait before trying again.
+func (b *TokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	if b.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// RateLimits returns a snapshot of the most recent rate limit information
+// reported by the API, keyed by request path.
+func (c *Client) RateLimits() map[string]Rate {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+
+	return maps.Clone(c.rateLimits)
+}
+
+// waitForRateLimit blocks until req may be sent according to the client-side
+// RateLimiter and, if WaitForRateLimit is enabled, the rate limit last
+// reported by the API for the request path.
+func (c *Client) waitForRateLimit(ctx context.Context, req *http.Request) error {
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	if !c.WaitForRateLimit {
+		return nil
+	}
+
+	c.rateMu.Lock()
+	rate, ok := c.rateLimits[req.URL.Path]
+	c.rateMu.Unlock()
+
+	if !ok || rate.Limit == 0 || rate.Remaining > 0 {
+		return nil
+	}
+
+	if delay := time.Until(rate.Reset); delay > 0 {
+		c.log(ctx, slog.LevelInfo, "mcp: waiting for rate limit reset",
+			slog.String("path", req.URL.Path),
+			slog.Time("reset", rate.Reset),
+			slog.Duration("delay", delay),
+		)
+		return sleep(ctx, delay)
+	}
+	return nil
+}
+
+// sleep pauses for the given duration or until ctx is done, in which case
+// ctx.Err() is returned.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
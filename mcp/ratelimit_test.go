@@ -0,0 +1,192 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := NewTokenBucket(10, 2)
+	b.last = start
+
+	// The bucket starts full, allowing a burst of two requests.
+	for i := range 2 {
+		if delay := b.reserve(start); delay != 0 {
+			t.Fatalf("reserve() #%d delay = %v, want 0", i+1, delay)
+		}
+	}
+
+	if delay := b.reserve(start); delay != 100*time.Millisecond {
+		t.Errorf("reserve() on empty bucket delay = %v, want %v", delay, 100*time.Millisecond)
+	}
+
+	if delay := b.reserve(start.Add(100 * time.Millisecond)); delay != 0 {
+		t.Errorf("reserve() after refill delay = %v, want 0", delay)
+	}
+
+	// Refills never exceed the burst size.
+	later := start.Add(time.Hour)
+	for i := range 2 {
+		if delay := b.reserve(later); delay != 0 {
+			t.Fatalf("reserve() #%d after long idle delay = %v, want 0", i+1, delay)
+		}
+	}
+	if delay := b.reserve(later); delay == 0 {
+		t.Error("reserve() beyond burst delay = 0, want > 0")
+	}
+}
+
+func TestTokenBucket_WaitCancelled(t *testing.T) {
+	b := NewTokenBucket(0.001, 1)
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := b.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDo_RateLimiter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	client.RateLimiter = NewTokenBucket(20, 1)
+
+	start := time.Now()
+	for range 3 {
+		req, _ := client.NewRequest(http.MethodGet, "test", nil)
+		if _, err := client.Do(context.Background(), req, nil); err != nil {
+			t.Fatalf("Do() unexpected error: %v", err)
+		}
+	}
+
+	// One request is allowed immediately, the next two wait ~50ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 requests at 20/s took %v, want at least 90ms", elapsed)
+	}
+}
+
+func TestDo_WaitForRateLimit(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	client.WaitForRateLimit = true
+	client.rateLimits["/test"] = Rate{
+		Limit:     100,
+		Remaining: 0,
+		Reset:     time.Now().Add(50 * time.Millisecond),
+	}
+
+	start := time.Now()
+	req, _ := client.NewRequest(http.MethodGet, "test", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Do() returned after %v, want it to wait for the rate limit reset", elapsed)
+	}
+}
+
+func TestDo_WaitForRateLimitCancelled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var called bool
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	client.WaitForRateLimit = true
+	client.rateLimits["/test"] = Rate{
+		Limit:     100,
+		Remaining: 0,
+		Reset:     time.Now().Add(time.Hour),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequest(http.MethodGet, "test", nil)
+	if _, err := client.Do(ctx, req, nil); err != context.DeadlineExceeded {
+		t.Errorf("Do() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if called {
+		t.Error("Do() sent the request despite an exhausted rate limit")
+	}
+}
+
+func TestDo_WaitForRateLimitIgnoresOtherPaths(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	client.WaitForRateLimit = true
+	client.rateLimits["/other"] = Rate{
+		Limit:     100,
+		Remaining: 0,
+		Reset:     time.Now().Add(time.Hour),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, _ := client.NewRequest(http.MethodGet, "test", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Errorf("Do() unexpected error: %v", err)
+	}
+}
+
+func TestClient_RateLimits(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "2024-01-01T12:00:00Z")
+		fmt.Fprint(w, `{"servers": [], "metadata": {}}`)
+	})
+
+	if got := client.RateLimits(); len(got) != 0 {
+		t.Errorf("RateLimits() before any request = %v, want empty", got)
+	}
+
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Fatalf("Servers.List returned error: %v", err)
+	}
+
+	limits := client.RateLimits()
+	want := Rate{
+		Limit:     100,
+		Remaining: 42,
+		Reset:     time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if got := limits["/v0.1/servers"]; got != want {
+		t.Errorf("RateLimits()[/v0.1/servers] = %+v, want %+v", got, want)
+	}
+
+	// Mutating the snapshot must not affect the client.
+	delete(limits, "/v0.1/servers")
+	if _, ok := client.RateLimits()["/v0.1/servers"]; !ok {
+		t.Error("RateLimits() returned a map aliasing the client's state")
+	}
+}
@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryableStatuses are the HTTP status codes retried by a RetryPolicy
+// that does not specify its own RetryableStatuses.
+var DefaultRetryableStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy configures how Client.Do retries failed requests.
+//
+// Only idempotent requests (GET and HEAD) are retried. A request is retried
+// when the transport fails or the API responds with one of the
+// RetryableStatuses. The delay before each retry grows exponentially from
+// MinBackoff up to MaxBackoff, unless the API asks for a specific delay with
+// a Retry-After or X-RateLimit-Reset header.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts made for a request,
+	// including the first one. Values below 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts. If the API requests a
+	// longer delay via Retry-After or X-RateLimit-Reset, the request is not
+	// retried and the error is returned to the caller.
+	MaxBackoff time.Duration
+
+	// Jitter randomizes each computed backoff by up to the given fraction
+	// (0.0 to 1.0) in either direction.
+	Jitter float64
+
+	// RetryableStatuses lists the HTTP status codes that trigger a retry.
+	// If nil, DefaultRetryableStatuses is used.
+	RetryableStatuses []int
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most callers: up to
+// four attempts with a jittered exponential backoff between 500ms and 30s.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// retryDelay reports whether a request that failed on the given attempt
+// should be retried and, if so, how long to wait before doing so. resp may
+// be nil when the transport failed before receiving a response.
+func (p *RetryPolicy) retryDelay(req *http.Request, attempt int, resp *Response, err error) (time.Duration, bool) {
+	if p == nil || err == nil || attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return 0, false
+	}
+
+	if req.Context().Err() != nil {
+		return 0, false
+	}
+
+	if resp != nil && resp.Response != nil {
+		statuses := p.RetryableStatuses
+		if statuses == nil {
+			statuses = DefaultRetryableStatuses
+		}
+		if !slices.Contains(statuses, resp.StatusCode) {
+			return 0, false
+		}
+
+		if delay, ok := serverRetryDelay(resp, time.Now()); ok {
+			if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+				return 0, false
+			}
+			return delay, true
+		}
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff returns the jittered exponential delay to wait after the given
+// attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.MinBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// serverRetryDelay extracts the delay requested by the API, if any, from the
+// Retry-After header or, for rate limited responses, from the rate limit
+// reset time.
+func serverRetryDelay(resp *Response, now time.Time) (time.Duration, bool) {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0), true
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && !resp.Rate.Reset.IsZero() {
+		return max(resp.Rate.Reset.Sub(now), 0), true
+	}
+
+	return 0, false
+}
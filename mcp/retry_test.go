@@ -0,0 +1,277 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	}
+	return client
+}
+
+func TestDo_RetriesRetryableStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		status       int
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "recovers after bad gateway",
+			failures:     1,
+			status:       http.StatusBadGateway,
+			wantAttempts: 2,
+			wantErr:      false,
+		},
+		{
+			name:         "recovers after rate limit",
+			failures:     2,
+			status:       http.StatusTooManyRequests,
+			wantAttempts: 3,
+			wantErr:      false,
+		},
+		{
+			name:         "gives up after max attempts",
+			failures:     5,
+			status:       http.StatusServiceUnavailable,
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "does not retry not found",
+			failures:     5,
+			status:       http.StatusNotFound,
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if int(attempts.Add(1)) <= tt.failures {
+					w.WriteHeader(tt.status)
+					fmt.Fprint(w, `{"message": "try again"}`)
+					return
+				}
+				fmt.Fprint(w, `{"name": "test"}`)
+			})
+
+			req, _ := client.NewRequest(http.MethodGet, "test", nil)
+			var result map[string]string
+			resp, err := client.Do(context.Background(), req, &result)
+
+			if tt.wantErr && err == nil {
+				t.Error("Do() expected error, got nil")
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Do() unexpected error: %v", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Errorf("Do() status = %d, want %d", resp.StatusCode, http.StatusOK)
+				}
+				if result["name"] != "test" {
+					t.Errorf("Do() decoded %v, want name=test", result)
+				}
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDo_DoesNotRetryNonIdempotent(t *testing.T) {
+	var attempts atomic.Int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	req, _ := client.NewRequest(http.MethodPost, "test", map[string]string{"name": "test"})
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Error("Do() expected error, got nil")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestDo_NoRetryPolicy(t *testing.T) {
+	var attempts atomic.Int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client.RetryPolicy = nil
+
+	req, _ := client.NewRequest(http.MethodGet, "test", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Error("Do() expected error, got nil")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestDo_RetryAfterExceedsMaxBackoff(t *testing.T) {
+	var attempts atomic.Int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := client.NewRequest(http.MethodGet, "test", nil)
+	_, err := client.Do(context.Background(), req, nil)
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Errorf("Do() error type = %T, want *RateLimitError", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestDo_RetryCancelledDuringBackoff(t *testing.T) {
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.RetryPolicy.MinBackoff = time.Hour
+	client.RetryPolicy.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequest(http.MethodGet, "test", nil)
+	_, err := client.Do(ctx, req, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Do() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestServerRetryDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    int
+		header    http.Header
+		rate      Rate
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{
+			name:      "retry-after seconds",
+			status:    http.StatusServiceUnavailable,
+			header:    http.Header{"Retry-After": []string{"7"}},
+			wantDelay: 7 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:      "retry-after http date",
+			status:    http.StatusServiceUnavailable,
+			header:    http.Header{"Retry-After": []string{now.Add(90 * time.Second).Format(http.TimeFormat)}},
+			wantDelay: 90 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:      "retry-after in the past",
+			status:    http.StatusServiceUnavailable,
+			header:    http.Header{"Retry-After": []string{now.Add(-time.Minute).Format(http.TimeFormat)}},
+			wantDelay: 0,
+			wantOK:    true,
+		},
+		{
+			name:      "rate limit reset",
+			status:    http.StatusTooManyRequests,
+			header:    http.Header{},
+			rate:      Rate{Limit: 100, Remaining: 0, Reset: now.Add(30 * time.Second)},
+			wantDelay: 30 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:   "rate limit reset ignored for other statuses",
+			status: http.StatusBadGateway,
+			header: http.Header{},
+			rate:   Rate{Limit: 100, Remaining: 0, Reset: now.Add(30 * time.Second)},
+			wantOK: false,
+		},
+		{
+			name:   "invalid retry-after",
+			status: http.StatusServiceUnavailable,
+			header: http.Header{"Retry-After": []string{"soon"}},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &Response{
+				Response: &http.Response{StatusCode: tt.status, Header: tt.header},
+				Rate:     tt.rate,
+			}
+
+			delay, ok := serverRetryDelay(resp, now)
+			if ok != tt.wantOK {
+				t.Fatalf("serverRetryDelay() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if delay != tt.wantDelay {
+				t.Errorf("serverRetryDelay() delay = %v, want %v", delay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{
+		MaxAttempts: 10,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+	}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 100 * time.Millisecond},
+		{attempt: 2, want: 200 * time.Millisecond},
+		{attempt: 3, want: 400 * time.Millisecond},
+		{attempt: 4, want: 800 * time.Millisecond},
+		{attempt: 5, want: time.Second},
+		{attempt: 9, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := p.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+
+	p.Jitter = 0.5
+	for range 100 {
+		got := p.backoff(2)
+		if got < 100*time.Millisecond || got > 300*time.Millisecond {
+			t.Fatalf("backoff(2) with jitter = %v, want within [100ms, 300ms]", got)
+		}
+	}
+}
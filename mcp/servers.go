@@ -2,9 +2,13 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
@@ -38,6 +42,14 @@ func (s *ServersService) List(ctx context.Context, opts *ServerListOptions) (*re
 		resp.NextCursor = servers.Metadata.NextCursor
 	}
 
+	if servers != nil {
+		s.client.log(ctx, slog.LevelDebug, "mcp: listed servers",
+			slog.String("cursor", req.URL.Query().Get("cursor")),
+			slog.String("next_cursor", servers.Metadata.NextCursor),
+			slog.Int("count", len(servers.Servers)),
+		)
+	}
+
 	return servers, resp, nil
 }
 
@@ -51,6 +63,21 @@ func (s *ServersService) List(ctx context.Context, opts *ServerListOptions) (*re
 //
 // MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-server
 func (s *ServersService) Get(ctx context.Context, serverName string, opts *ServerGetOptions) (*registryv0.ServerJSON, *Response, error) {
+	serverResp, resp, err := s.GetWithMeta(ctx, serverName, opts)
+	return unwrapServer(serverResp), resp, err
+}
+
+// GetWithMeta is like Get, but returns the full ServerResponse including the
+// registry metadata (status, publishedAt, updatedAt, isLatest) in Meta.Official.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-server
+func (s *ServersService) GetWithMeta(ctx context.Context, serverName string, opts *ServerGetOptions) (*registryv0.ServerResponse, *Response, error) {
+	return s.getWithMeta(ctx, serverName, opts, false)
+}
+
+// getWithMeta implements GetWithMeta. If noCache is set, a response held in
+// the Client's Cache is revalidated with the API rather than served as is.
+func (s *ServersService) getWithMeta(ctx context.Context, serverName string, opts *ServerGetOptions, noCache bool) (*registryv0.ServerResponse, *Response, error) {
 	// URL-encode the server name to handle forward slashes
 	encodedName := url.PathEscape(serverName)
 
@@ -66,6 +93,9 @@ func (s *ServersService) Get(ctx context.Context, serverName string, opts *Serve
 	if err != nil {
 		return nil, nil, err
 	}
+	if noCache {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
 
 	var serverResp *registryv0.ServerResponse
 	resp, err := s.client.Do(ctx, req, &serverResp)
@@ -73,12 +103,7 @@ func (s *ServersService) Get(ctx context.Context, serverName string, opts *Serve
 		return nil, resp, err
 	}
 
-	// Unwrap ServerResponse to get the ServerJSON
-	if serverResp == nil {
-		return nil, resp, nil
-	}
-
-	return &serverResp.Server, resp, nil
+	return serverResp, resp, nil
 }
 
 // ListVersionsByName retrieves all available versions for a specific server by its server name.
@@ -88,150 +113,233 @@ func (s *ServersService) Get(ctx context.Context, serverName string, opts *Serve
 //
 // MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-server-versions
 func (s *ServersService) ListVersionsByName(ctx context.Context, serverName string) ([]registryv0.ServerJSON, *Response, error) {
-	// URL-encode the server name to handle forward slashes
-	encodedName := url.PathEscape(serverName)
-	u := fmt.Sprintf("v0.1/servers/%s/versions", encodedName)
-
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	var serverResp *registryv0.ServerListResponse
-	resp, err := s.client.Do(ctx, req, &serverResp)
+	servers, resp, err := s.ListVersionsByNameWithMeta(ctx, serverName)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	// Extract servers from the response, unwrapping ServerResponse to ServerJSON
-	var servers []registryv0.ServerJSON
-	if serverResp != nil && serverResp.Servers != nil {
-		servers = make([]registryv0.ServerJSON, len(serverResp.Servers))
-		for i, serverResponse := range serverResp.Servers {
-			servers[i] = serverResponse.Server
-		}
-	}
+	return unwrapServers(servers), resp, nil
+}
 
-	return servers, resp, nil
+// ListVersionsByNameWithMeta is like ListVersionsByName, but returns the full
+// ServerResponse of each version including its registry metadata.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-server-versions
+func (s *ServersService) ListVersionsByNameWithMeta(ctx context.Context, serverName string) ([]registryv0.ServerResponse, *Response, error) {
+	ctx, span := s.client.startSpan(ctx, "Servers.ListVersionsByName")
+	span.SetAttribute("mcp.server.name", serverName)
+	versions, resp, err := s.listVersions(ctx, serverName)
+	endSpan(span, err)
+
+	return versions, resp, err
 }
 
-// ListAll fetches all pages of results for servers.
-// This is a convenience method that handles pagination automatically.
-func (s *ServersService) ListAll(ctx context.Context, opts *ServerListOptions) ([]registryv0.ServerJSON, *Response, error) {
-	if opts == nil {
-		opts = &ServerListOptions{}
-	}
+// listVersions implements ListVersionsByNameWithMeta, following the
+// NextCursor of each page.
+func (s *ServersService) listVersions(ctx context.Context, serverName string) ([]registryv0.ServerResponse, *Response, error) {
+	// URL-encode the server name to handle forward slashes
+	encodedName := url.PathEscape(serverName)
+	u := fmt.Sprintf("v0.1/servers/%s/versions", encodedName)
 
-	var allServers []registryv0.ServerJSON
-	var lastResp *Response
+	var versions []registryv0.ServerResponse
+	opts := &ListOptions{}
 
+	// Follow NextCursor in case the registry pages the versions
 	for {
-		resp, httpResp, err := s.List(ctx, opts)
+		pageURL, err := addOptions(u, opts)
 		if err != nil {
-			return allServers, httpResp, err
+			return nil, nil, err
 		}
 
-		lastResp = httpResp
+		req, err := s.client.NewRequest(http.MethodGet, pageURL, nil)
+		if err != nil {
+			return nil, nil, err
+		}
 
-		// Unwrap ServerResponse to ServerJSON for each server
-		if resp.Servers != nil {
-			for _, serverResponse := range resp.Servers {
-				allServers = append(allServers, serverResponse.Server)
-			}
+		var serverResp *registryv0.ServerListResponse
+		resp, err := s.client.Do(ctx, req, &serverResp)
+		if err != nil {
+			return nil, resp, err
 		}
 
-		// Check if there are more pages
-		if resp.Metadata.NextCursor == "" {
-			break
+		if serverResp == nil {
+			return versions, resp, nil
 		}
+		versions = append(versions, serverResp.Servers...)
 
-		// Update cursor for next request
-		opts.Cursor = resp.Metadata.NextCursor
+		next := serverResp.Metadata.NextCursor
+		if next == "" || next == opts.Cursor {
+			return versions, resp, nil
+		}
+		opts.Cursor = next
 	}
-
-	return allServers, lastResp, nil
 }
 
-// ListByName retrieves all servers with the specified name.
-// Since each server can have multiple versions in the registry,
-// this method returns a slice containing all matching servers.
-// Returns an empty slice if no matches are found.
-func (s *ServersService) ListByName(ctx context.Context, name string) ([]registryv0.ServerJSON, *Response, error) {
-	opts := &ServerListOptions{
-		Search: name,
-		ListOptions: ListOptions{
-			Limit: 100,
-		},
+// IterPages returns an iterator over pages of servers matching opts. Pages
+// are fetched lazily, one API request per page, and fetching stops as soon
+// as the consumer stops iterating. The caller's opts are not modified.
+//
+// If a request fails, the iterator yields a page holding only the Response
+// (which may be nil) together with the error, and then stops.
+func (s *ServersService) IterPages(ctx context.Context, opts *ServerListOptions) iter.Seq2[*ServerPage, error] {
+	return func(yield func(*ServerPage, error) bool) {
+		ctx, span := s.client.startSpan(ctx, "Servers.IterPages")
+		var err error
+		defer func() { endSpan(span, err) }()
+
+		for page, pageErr := range s.iterPages(ctx, opts) {
+			err = pageErr
+			if !yield(page, pageErr) {
+				return
+			}
+		}
 	}
+}
 
-	var matchingServers []registryv0.ServerJSON
-	var lastResp *Response
-
-	for {
-		resp, httpResp, err := s.List(ctx, opts)
-		if err != nil {
-			return nil, httpResp, err
+// iterPages implements IterPages without starting a span of its own.
+func (s *ServersService) iterPages(ctx context.Context, opts *ServerListOptions) iter.Seq2[*ServerPage, error] {
+	return func(yield func(*ServerPage, error) bool) {
+		var pageOpts ServerListOptions
+		if opts != nil {
+			pageOpts = *opts
 		}
 
-		lastResp = httpResp
+		for {
+			servers, resp, err := s.List(ctx, &pageOpts)
+			if err != nil {
+				yield(&ServerPage{Response: resp}, err)
+				return
+			}
 
-		// Collect all exact matches, unwrapping ServerResponse to ServerJSON
-		for _, serverResponse := range resp.Servers {
-			if serverResponse.Server.Name == name {
-				matchingServers = append(matchingServers, serverResponse.Server)
+			page := &ServerPage{Response: resp}
+			if servers != nil {
+				page.Servers = servers.Servers
+				page.Metadata = servers.Metadata
+			}
+			if !yield(page, nil) {
+				return
+			}
+
+			// Check if there are more pages
+			if page.Metadata.NextCursor == "" {
+				return
 			}
-		}
 
-		// Check if there are more pages
-		if resp.Metadata.NextCursor == "" {
-			break
+			pageOpts.Cursor = page.Metadata.NextCursor
 		}
+	}
+}
 
-		opts.Cursor = resp.Metadata.NextCursor
+// Iter returns an iterator over all servers matching opts, fetching pages
+// lazily as the consumer advances. Fetching stops as soon as the consumer
+// stops iterating. If a request fails, the iterator yields the error once
+// and then stops.
+//
+//	for server, err := range client.Servers.Iter(ctx, opts) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(server.Server.Name)
+//	}
+func (s *ServersService) Iter(ctx context.Context, opts *ServerListOptions) iter.Seq2[registryv0.ServerResponse, error] {
+	return func(yield func(registryv0.ServerResponse, error) bool) {
+		for page, err := range s.IterPages(ctx, opts) {
+			if err != nil {
+				yield(registryv0.ServerResponse{}, err)
+				return
+			}
+
+			for _, server := range page.Servers {
+				if !yield(server, nil) {
+					return
+				}
+			}
+		}
 	}
+}
 
-	return matchingServers, lastResp, nil
+// ListAll fetches all pages of results for servers.
+// This is a convenience method that handles pagination automatically.
+func (s *ServersService) ListAll(ctx context.Context, opts *ServerListOptions) ([]registryv0.ServerJSON, *Response, error) {
+	servers, resp, err := s.ListAllWithMeta(ctx, opts)
+	return unwrapServers(servers), resp, err
 }
 
-// GetByNameLatest retrieves the latest version of a server with the specified name.
-// This method uses the version=latest query parameter to filter results to only
-// the latest version, then returns the match.
-// Returns nil if no latest version is found.
-func (s *ServersService) GetByNameLatest(ctx context.Context, name string) (*registryv0.ServerJSON, *Response, error) {
-	opts := &ServerListOptions{
-		Search:  name,
-		Version: "latest",
-		ListOptions: ListOptions{
-			Limit: 100,
-		},
-	}
+// ListAllWithMeta is like ListAll, but returns the full ServerResponse of
+// each server including its registry metadata.
+func (s *ServersService) ListAllWithMeta(ctx context.Context, opts *ServerListOptions) ([]registryv0.ServerResponse, *Response, error) {
+	ctx, span := s.client.startSpan(ctx, "Servers.ListAll")
+	servers, resp, err := s.listAll(ctx, opts)
+	span.SetAttribute("mcp.servers.count", len(servers))
+	endSpan(span, err)
+
+	return servers, resp, err
+}
 
+// listAll implements ListAllWithMeta.
+func (s *ServersService) listAll(ctx context.Context, opts *ServerListOptions) ([]registryv0.ServerResponse, *Response, error) {
+	var allServers []registryv0.ServerResponse
 	var lastResp *Response
 
-	for {
-		resp, httpResp, err := s.List(ctx, opts)
+	for page, err := range s.iterPages(ctx, opts) {
+		lastResp = page.Response
 		if err != nil {
-			return nil, httpResp, err
+			return allServers, lastResp, err
 		}
 
-		lastResp = httpResp
+		allServers = append(allServers, page.Servers...)
+	}
 
-		// Look for exact match, unwrapping ServerResponse to ServerJSON
-		for _, serverResponse := range resp.Servers {
-			if serverResponse.Server.Name == name {
-				return &serverResponse.Server, lastResp, nil
-			}
-		}
+	return allServers, lastResp, nil
+}
 
-		// Check if there are more pages
-		if resp.Metadata.NextCursor == "" {
-			break
-		}
+// ListByName retrieves all versions of the server with the specified name
+// from the versions endpoint.
+// Returns an empty slice if the server does not exist.
+func (s *ServersService) ListByName(ctx context.Context, name string) ([]registryv0.ServerJSON, *Response, error) {
+	servers, resp, err := s.ListByNameWithMeta(ctx, name)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return unwrapServers(servers), resp, nil
+}
+
+// ListByNameWithMeta is like ListByName, but returns the full ServerResponse
+// of each version including its registry metadata.
+func (s *ServersService) ListByNameWithMeta(ctx context.Context, name string) ([]registryv0.ServerResponse, *Response, error) {
+	return s.versionsByName(ctx, name)
+}
+
+// GetByNameLatest retrieves the latest version of a server with the specified name.
+// This method lists the server's versions and returns the one the registry
+// flags as latest, or the newest according to the client's VersionComparator
+// if none is flagged.
+// Returns nil if the server does not exist.
+func (s *ServersService) GetByNameLatest(ctx context.Context, name string) (*registryv0.ServerJSON, *Response, error) {
+	server, resp, err := s.GetByNameLatestWithMeta(ctx, name)
+	return unwrapServer(server), resp, err
+}
+
+// GetByNameLatestWithMeta is like GetByNameLatest, but returns the full
+// ServerResponse including its registry metadata.
+func (s *ServersService) GetByNameLatestWithMeta(ctx context.Context, name string) (*registryv0.ServerResponse, *Response, error) {
+	versions, resp, err := s.versionsByName(ctx, name)
+	if err != nil {
+		return nil, resp, err
+	}
 
-		opts.Cursor = resp.Metadata.NextCursor
+	var latestServer *registryv0.ServerResponse
+	for i, serverResponse := range versions {
+		if isLatest(serverResponse) {
+			return &versions[i], resp, nil
+		}
+		if latestServer == nil || s.client.compareVersions(serverResponse, *latestServer) > 0 {
+			latestServer = &versions[i]
+		}
 	}
 
-	return nil, lastResp, nil
+	return latestServer, resp, nil
 }
 
 // GetByNameExactVersion retrieves a specific version of a server with the specified name.
@@ -242,88 +350,120 @@ func (s *ServersService) GetByNameLatest(ctx context.Context, name string) (*reg
 //
 // Returns nil if no matching version is found.
 func (s *ServersService) GetByNameExactVersion(ctx context.Context, name, version string) (*registryv0.ServerJSON, *Response, error) {
-	// URL-encode the server name and version to handle forward slashes and special characters
-	encodedName := url.PathEscape(name)
-	encodedVersion := url.PathEscape(version)
-	u := fmt.Sprintf("v0.1/servers/%s/versions/%s", encodedName, encodedVersion)
+	server, resp, err := s.GetByNameExactVersionWithMeta(ctx, name, version)
+	return unwrapServer(server), resp, err
+}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
-	if err != nil {
-		return nil, nil, err
-	}
+// GetByNameExactVersionWithMeta is like GetByNameExactVersion, but returns
+// the full ServerResponse including its registry metadata.
+func (s *ServersService) GetByNameExactVersionWithMeta(ctx context.Context, name, version string) (*registryv0.ServerResponse, *Response, error) {
+	return s.GetWithMeta(ctx, name, &ServerGetOptions{Version: version})
+}
 
-	var serverResp *registryv0.ServerResponse
-	resp, err := s.client.Do(ctx, req, &serverResp)
+// GetByNameLatestActiveVersion retrieves the latest active version of a server with the specified name.
+// This method lists the server's versions, keeps those with Status == "active",
+// then orders them with the client's VersionComparator, CompareVersions by default,
+// to determine the latest version. Versions that are not semantic versions are
+// ordered by publish time. Returns nil if no active versions are found.
+func (s *ServersService) GetByNameLatestActiveVersion(ctx context.Context, name string) (*registryv0.ServerJSON, *Response, error) {
+	server, resp, err := s.GetByNameLatestActiveVersionWithMeta(ctx, name)
+	return unwrapServer(server), resp, err
+}
+
+// GetByNameLatestActiveVersionWithMeta is like GetByNameLatestActiveVersion,
+// but returns the full ServerResponse including its registry metadata.
+func (s *ServersService) GetByNameLatestActiveVersionWithMeta(ctx context.Context, name string) (*registryv0.ServerResponse, *Response, error) {
+	versions, resp, err := s.versionsByName(ctx, name)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	// Unwrap ServerResponse to get the ServerJSON
-	if serverResp == nil {
-		return nil, resp, nil
+	var latestServer *registryv0.ServerResponse
+	for i, serverResponse := range versions {
+		// Note: Status has moved from ServerJSON to ServerResponse.Meta.Official.Status
+		if serverResponse.Meta.Official == nil || serverResponse.Meta.Official.Status != model.StatusActive {
+			continue
+		}
+
+		// Keep track of the latest version
+		if latestServer == nil || s.client.compareVersions(serverResponse, *latestServer) > 0 {
+			latestServer = &versions[i]
+		}
 	}
 
-	return &serverResp.Server, resp, nil
+	return latestServer, resp, nil
 }
 
-// GetByNameLatestActiveVersion retrieves the latest active version of a server with the specified name.
-// This method performs client-side filtering to find servers with Status == "active",
-// then uses semantic version comparison to determine the latest version.
-// Returns nil if no active versions are found.
-func (s *ServersService) GetByNameLatestActiveVersion(ctx context.Context, name string) (*registryv0.ServerJSON, *Response, error) {
-	opts := &ServerListOptions{
-		Search: name,
-		ListOptions: ListOptions{
-			Limit: 100,
-		},
+// Resolve returns the highest version of the server with the given name that
+// satisfies a semantic version constraint, such as "^1.2", "~2.0.3" or
+// ">=1.0 <2". An empty constraint matches any version.
+//
+// Only active versions are considered unless opts.IncludeDeprecated is set,
+// and prereleases only if opts.IncludePrerelease is set or the constraint
+// names a prerelease. Versions that are not semantic versions, as defined by
+// CompareVersions, or that lack registry metadata are skipped. If no version matches, the returned
+// Resolution has a nil Match.
+func (s *ServersService) Resolve(ctx context.Context, name, constraint string, opts *ResolveOptions) (*Resolution, *Response, error) {
+	if constraint == "" {
+		constraint = "*"
+	}
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
 	}
 
-	var latestServer *registryv0.ServerJSON
-	var latestVersion *semver.Version
-	var lastResp *Response
-
-	for {
-		resp, httpResp, err := s.List(ctx, opts)
-		if err != nil {
-			return nil, httpResp, err
-		}
+	if opts == nil {
+		opts = &ResolveOptions{}
+	}
+	constraints.IncludePrerelease = opts.IncludePrerelease
 
-		lastResp = httpResp
+	serverResponses, resp, err := s.ListVersionsByNameWithMeta(ctx, name)
+	if err != nil {
+		return nil, resp, err
+	}
 
-		// Look for active servers with exact name match
-		// Note: Status has moved from ServerJSON to ServerResponse.Meta.Official.Status
-		for _, serverResponse := range resp.Servers {
-			// Check if server has official metadata with status
-			if serverResponse.Meta.Official == nil {
-				continue
-			}
+	type candidate struct {
+		version        *semver.Version
+		serverResponse registryv0.ServerResponse
+	}
+	var candidates []candidate
 
-			if serverResponse.Server.Name == name && serverResponse.Meta.Official.Status == model.StatusActive {
-				// Try to parse the version as semantic version
-				version, err := semver.NewVersion(serverResponse.Server.Version)
-				if err != nil {
-					// Skip servers with invalid semantic versions
-					continue
-				}
+	for _, serverResponse := range serverResponses {
+		if serverResponse.Server.Name != name || serverResponse.Meta.Official == nil {
+			continue
+		}
 
-				// Keep track of the latest version
-				if latestVersion == nil || version.GreaterThan(latestVersion) {
-					latestVersion = version
-					serverCopy := serverResponse.Server // Create a copy to avoid pointer issues
-					latestServer = &serverCopy
-				}
+		switch serverResponse.Meta.Official.Status {
+		case model.StatusActive:
+		case model.StatusDeprecated:
+			if !opts.IncludeDeprecated {
+				continue
 			}
+		default:
+			continue
 		}
 
-		// Check if there are more pages
-		if resp.Metadata.NextCursor == "" {
-			break
+		version, err := parseStrictSemver(serverResponse.Server.Version)
+		if err != nil || !constraints.Check(version) {
+			continue
 		}
 
-		opts.Cursor = resp.Metadata.NextCursor
+		candidates = append(candidates, candidate{version, serverResponse})
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return b.version.Compare(a.version)
+	})
+
+	resolution := &Resolution{}
+	for _, c := range candidates {
+		resolution.Candidates = append(resolution.Candidates, c.serverResponse)
+	}
+	if len(resolution.Candidates) > 0 {
+		resolution.Match = &resolution.Candidates[0]
 	}
 
-	return latestServer, lastResp, nil
+	return resolution, resp, nil
 }
 
 // ListByUpdatedSince retrieves all servers that have been updated since the specified timestamp.
@@ -331,6 +471,13 @@ func (s *ServersService) GetByNameLatestActiveVersion(ctx context.Context, name
 // The timestamp should be in RFC3339 format.
 // Returns an empty slice if no servers have been updated since the timestamp.
 func (s *ServersService) ListByUpdatedSince(ctx context.Context, since time.Time) ([]registryv0.ServerJSON, *Response, error) {
+	servers, resp, err := s.ListByUpdatedSinceWithMeta(ctx, since)
+	return unwrapServers(servers), resp, err
+}
+
+// ListByUpdatedSinceWithMeta is like ListByUpdatedSince, but returns the full
+// ServerResponse of each server including its registry metadata.
+func (s *ServersService) ListByUpdatedSinceWithMeta(ctx context.Context, since time.Time) ([]registryv0.ServerResponse, *Response, error) {
 	opts := &ServerListOptions{
 		UpdatedSince: &since,
 		ListOptions: ListOptions{
@@ -338,31 +485,153 @@ func (s *ServersService) ListByUpdatedSince(ctx context.Context, since time.Time
 		},
 	}
 
-	var updatedServers []registryv0.ServerJSON
-	var lastResp *Response
+	return s.ListAllWithMeta(ctx, opts)
+}
 
-	for {
-		resp, httpResp, err := s.List(ctx, opts)
-		if err != nil {
-			return updatedServers, httpResp, err
-		}
+// Publish publishes a server version described by server.json to the
+// registry and returns the created ServerResponse, including the registry
+// metadata assigned to it.
+//
+// Publishing requires a registry JWT, set with Client.SetAuthToken or
+// WithAuthToken. If the registry rejects server.json, the returned error
+// matches ErrValidation and its ErrorResponse.Errors describe each problem.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/publish-server
+func (s *ServersService) Publish(ctx context.Context, server *registryv0.ServerJSON) (*registryv0.ServerResponse, *Response, error) {
+	if server == nil {
+		return nil, nil, errors.New("server must not be nil")
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "v0.1/publish", server)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var serverResp *registryv0.ServerResponse
+	resp, err := s.client.Do(ctx, req, &serverResp)
+	if err != nil {
+		return nil, resp, err
+	}
+	s.client.invalidateServer(server.Name)
 
-		lastResp = httpResp
+	return serverResp, resp, nil
+}
 
-		// Unwrap ServerResponse to ServerJSON for each server
-		if resp.Servers != nil {
-			for _, serverResponse := range resp.Servers {
-				updatedServers = append(updatedServers, serverResponse.Server)
-			}
-		}
+// Edit replaces the server.json of a published version, optionally changing
+// its status. The name and version in server must match serverName and
+// version; servers cannot be renamed. Editing requires a registry JWT with
+// edit permissions for the server, typically an administrator's.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/edit-server
+func (s *ServersService) Edit(ctx context.Context, serverName, version string, server *registryv0.ServerJSON, opts *ServerEditOptions) (*registryv0.ServerResponse, *Response, error) {
+	if server == nil {
+		return nil, nil, errors.New("server must not be nil")
+	}
+	if server.Name != serverName {
+		return nil, nil, fmt.Errorf("server name %q does not match %q: servers cannot be renamed", server.Name, serverName)
+	}
+	if server.Version != version {
+		return nil, nil, fmt.Errorf("server version %q does not match %q", server.Version, version)
+	}
+
+	u := fmt.Sprintf("v0.1/servers/%s/versions/%s", url.PathEscape(serverName), url.PathEscape(version))
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodPut, u, server)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var serverResp *registryv0.ServerResponse
+	resp, err := s.client.Do(ctx, req, &serverResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return serverResp, resp, nil
+}
+
+// UpdateStatus changes the status of a published version to active,
+// deprecated or deleted. Setting the status of a deprecated version back to
+// active restores it; deleted versions cannot be restored and the registry
+// rejects the change with a validation error.
+//
+// The version's current server.json is fetched, bypassing any fresh cached
+// copy, and sent back unchanged with the new status. Errors from either
+// request are returned as is, so a version that does not exist satisfies
+// IsNotFound.
+func (s *ServersService) UpdateStatus(ctx context.Context, serverName, version string, status model.Status) (*registryv0.ServerResponse, *Response, error) {
+	ctx, span := s.client.startSpan(ctx, "Servers.UpdateStatus")
+	span.SetAttribute("mcp.server.name", serverName)
+	span.SetAttribute("mcp.server.version", version)
+	server, resp, err := s.updateStatus(ctx, serverName, version, status)
+	endSpan(span, err)
+
+	return server, resp, err
+}
+
+// updateStatus implements UpdateStatus.
+func (s *ServersService) updateStatus(ctx context.Context, serverName, version string, status model.Status) (*registryv0.ServerResponse, *Response, error) {
+	switch status {
+	case model.StatusActive, model.StatusDeprecated, model.StatusDeleted:
+	default:
+		return nil, nil, fmt.Errorf("invalid status %q: must be %q, %q or %q",
+			status, model.StatusActive, model.StatusDeprecated, model.StatusDeleted)
+	}
+
+	// Bypass the cache so that newer edits are not overwritten
+	current, resp, err := s.getWithMeta(ctx, serverName, &ServerGetOptions{Version: version}, true)
+	if err != nil {
+		return nil, resp, err
+	}
+	if current == nil {
+		return nil, resp, fmt.Errorf("server %q version %q not returned by the registry", serverName, version)
+	}
+
+	return s.Edit(ctx, serverName, version, &current.Server, &ServerEditOptions{Status: status})
+}
 
-		// Check if there are more pages
-		if resp.Metadata.NextCursor == "" {
-			break
+// versionsByName lists the versions of the server with the specified name,
+// treating a server that does not exist as having no versions.
+func (s *ServersService) versionsByName(ctx context.Context, name string) ([]registryv0.ServerResponse, *Response, error) {
+	versions, resp, err := s.ListVersionsByNameWithMeta(ctx, name)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, resp, nil
 		}
+		return nil, resp, err
+	}
+
+	// Guard against registries that return other servers
+	versions = slices.DeleteFunc(versions, func(serverResponse registryv0.ServerResponse) bool {
+		return serverResponse.Server.Name != name
+	})
 
-		opts.Cursor = resp.Metadata.NextCursor
+	return versions, resp, nil
+}
+
+// unwrapServer returns the ServerJSON wrapped by serverResp, or nil if
+// serverResp is nil.
+func unwrapServer(serverResp *registryv0.ServerResponse) *registryv0.ServerJSON {
+	if serverResp == nil {
+		return nil
 	}
+	return &serverResp.Server
+}
 
-	return updatedServers, lastResp, nil
+// unwrapServers returns the ServerJSON wrapped by each ServerResponse. A nil
+// slice is returned as nil.
+func unwrapServers(serverResps []registryv0.ServerResponse) []registryv0.ServerJSON {
+	if serverResps == nil {
+		return nil
+	}
+
+	servers := make([]registryv0.ServerJSON, len(serverResps))
+	for i, serverResponse := range serverResps {
+		servers[i] = serverResponse.Server
+	}
+	return servers
 }
@@ -2,11 +2,16 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -387,6 +392,45 @@ func TestServersService_ListVersionsByName(t *testing.T) {
 	}
 }
 
+func TestServersService_ListVersionsByName_Pagination(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			testFormValues(t, r, values{})
+			fmt.Fprint(w, `{
+				"servers": [{"server": {"name": "test/server", "version": "1.0.0"}}],
+				"metadata": {"nextCursor": "page2"}
+			}`)
+		case "page2":
+			testFormValues(t, r, values{"cursor": "page2"})
+			fmt.Fprint(w, `{
+				"servers": [{"server": {"name": "test/server", "version": "2.0.0"}}],
+				"metadata": {}
+			}`)
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	})
+
+	servers, _, err := client.Servers.ListVersionsByName(context.Background(), "test/server")
+	if err != nil {
+		t.Fatalf("Servers.ListVersionsByName returned error: %v", err)
+	}
+
+	var got []string
+	for _, server := range servers {
+		got = append(got, server.Version)
+	}
+	if want := []string{"1.0.0", "2.0.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Servers.ListVersionsByName versions = %v, want %v", got, want)
+	}
+}
+
 func TestServersService_ListAll(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -445,11 +489,158 @@ func TestServersService_ListAll(t *testing.T) {
 	}
 }
 
+// pagedServersHandler serves the given pages of server names from
+// /v0.1/servers, chaining them with "pageN" cursors, and counts requests.
+func pagedServersHandler(t *testing.T, requests *int, pages ...[]string) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		*requests++
+
+		index := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			fmt.Sscanf(cursor, "page%d", &index)
+		}
+
+		var servers []string
+		for _, name := range pages[index] {
+			servers = append(servers, fmt.Sprintf(`{"server": {"name": %q, "version": "1.0.0"}}`, name))
+		}
+
+		nextCursor := ""
+		if index+1 < len(pages) {
+			nextCursor = fmt.Sprintf("page%d", index+1)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"servers": [%s], "metadata": {"nextCursor": %q}}`, strings.Join(servers, ","), nextCursor)
+	}
+}
+
+func TestServersService_Iter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers", pagedServersHandler(t, &requests,
+		[]string{"server1", "server2"},
+		[]string{"server3"},
+		[]string{"server4", "server5"},
+	))
+
+	var names []string
+	for server, err := range client.Servers.Iter(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("Servers.Iter yielded error: %v", err)
+		}
+		names = append(names, server.Server.Name)
+	}
+
+	want := []string{"server1", "server2", "server3", "server4", "server5"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Servers.Iter yielded %v, want %v", names, want)
+	}
+	if requests != 3 {
+		t.Errorf("Servers.Iter made %d requests, want 3", requests)
+	}
+}
+
+func TestServersService_Iter_StopsFetchingOnBreak(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers", pagedServersHandler(t, &requests,
+		[]string{"server1", "server2"},
+		[]string{"server3"},
+		[]string{"server4"},
+	))
+
+	var names []string
+	for server, err := range client.Servers.Iter(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("Servers.Iter yielded error: %v", err)
+		}
+		names = append(names, server.Server.Name)
+		if server.Server.Name == "server2" {
+			break
+		}
+	}
+
+	if want := []string{"server1", "server2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Servers.Iter yielded %v, want %v", names, want)
+	}
+	if requests != 1 {
+		t.Errorf("Servers.Iter made %d requests after break, want 1", requests)
+	}
+}
+
+func TestServersService_Iter_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"servers": [{"server": {"name": "server1"}}], "metadata": {"nextCursor": "page1"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	var names []string
+	var errs int
+	for server, err := range client.Servers.Iter(context.Background(), nil) {
+		if err != nil {
+			errs++
+			continue
+		}
+		names = append(names, server.Server.Name)
+	}
+
+	if want := []string{"server1"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Servers.Iter yielded %v, want %v", names, want)
+	}
+	if errs != 1 {
+		t.Errorf("Servers.Iter yielded %d errors, want 1", errs)
+	}
+}
+
+func TestServersService_IterPages(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers", pagedServersHandler(t, &requests,
+		[]string{"server1", "server2"},
+		[]string{"server3"},
+	))
+
+	opts := &ServerListOptions{ListOptions: ListOptions{Limit: 2}}
+
+	var sizes []int
+	for page, err := range client.Servers.IterPages(context.Background(), opts) {
+		if err != nil {
+			t.Fatalf("Servers.IterPages yielded error: %v", err)
+		}
+		if page.Response == nil {
+			t.Error("Servers.IterPages yielded page without Response")
+		}
+		sizes = append(sizes, len(page.Servers))
+	}
+
+	if want := []int{2, 1}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("Servers.IterPages page sizes = %v, want %v", sizes, want)
+	}
+	if opts.Cursor != "" {
+		t.Errorf("Servers.IterPages modified caller opts cursor to %q", opts.Cursor)
+	}
+}
+
 func TestServersService_ListByName(t *testing.T) {
 	tests := []struct {
 		name            string
 		searchName      string
-		expectedQuery   values
+		responseStatus  int
 		responseBody    string
 		expectedResults []registryv0.ServerJSON
 		expectError     bool
@@ -457,10 +648,6 @@ func TestServersService_ListByName(t *testing.T) {
 		{
 			name:       "single exact match found",
 			searchName: "exact-name",
-			expectedQuery: values{
-				"search": "exact-name",
-				"limit":  "100",
-			},
 			responseBody: `{
 				"servers": [
 					{
@@ -485,10 +672,6 @@ func TestServersService_ListByName(t *testing.T) {
 		{
 			name:       "multiple versions of same server",
 			searchName: "test-server",
-			expectedQuery: values{
-				"search": "test-server",
-				"limit":  "100",
-			},
 			responseBody: `{
 				"servers": [
 					{
@@ -523,13 +706,10 @@ func TestServersService_ListByName(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name:       "no servers found",
-			searchName: "nonexistent",
-			expectedQuery: values{
-				"search": "nonexistent",
-				"limit":  "100",
-			},
-			responseBody:    `{"servers": [], "metadata": {}}`,
+			name:            "no servers found",
+			searchName:      "nonexistent",
+			responseStatus:  http.StatusNotFound,
+			responseBody:    `{"title": "Not Found", "status": 404, "detail": "Server not found"}`,
 			expectedResults: []registryv0.ServerJSON{},
 			expectError:     false,
 		},
@@ -540,11 +720,14 @@ func TestServersService_ListByName(t *testing.T) {
 			client, mux, _, teardown := setup()
 			defer teardown()
 
-			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+			mux.HandleFunc("/v0.1/servers/"+tt.searchName+"/versions", func(w http.ResponseWriter, r *http.Request) {
 				testMethod(t, r, "GET")
-				testFormValues(t, r, tt.expectedQuery)
+				testFormValues(t, r, values{})
 
 				w.Header().Set("Content-Type", "application/json")
+				if tt.responseStatus != 0 {
+					w.WriteHeader(tt.responseStatus)
+				}
 				fmt.Fprint(w, tt.responseBody)
 			})
 
@@ -585,7 +768,7 @@ func TestServersService_GetByNameLatest(t *testing.T) {
 	tests := []struct {
 		name           string
 		searchName     string
-		expectedQuery  values
+		responseStatus int
 		responseBody   string
 		expectedResult *registryv0.ServerJSON
 		expectNil      bool
@@ -593,11 +776,6 @@ func TestServersService_GetByNameLatest(t *testing.T) {
 		{
 			name:       "latest version found",
 			searchName: "test-server",
-			expectedQuery: values{
-				"search":  "test-server",
-				"version": "latest",
-				"limit":   "100",
-			},
 			responseBody: `{
 				"servers": [
 					{
@@ -624,11 +802,6 @@ func TestServersService_GetByNameLatest(t *testing.T) {
 		{
 			name:       "exact match among multiple similar names",
 			searchName: "exact-name",
-			expectedQuery: values{
-				"search":  "exact-name",
-				"version": "latest",
-				"limit":   "100",
-			},
 			responseBody: `{
 				"servers": [
 					{
@@ -649,15 +822,33 @@ func TestServersService_GetByNameLatest(t *testing.T) {
 			expectNil: false,
 		},
 		{
-			name:       "server not found",
-			searchName: "nonexistent",
-			expectedQuery: values{
-				"search":  "nonexistent",
-				"version": "latest",
-				"limit":   "100",
+			name:       "registry latest flag preferred",
+			searchName: "test-server",
+			responseBody: `{
+				"servers": [
+					{
+						"server": {"name": "test-server", "version": "1.1.0"},
+						"_meta": {"io.modelcontextprotocol.registry/official": {"status": "active", "isLatest": true}}
+					},
+					{
+						"server": {"name": "test-server", "version": "2.0.0-beta.1"},
+						"_meta": {"io.modelcontextprotocol.registry/official": {"status": "active"}}
+					}
+				],
+				"metadata": {}
+			}`,
+			expectedResult: &registryv0.ServerJSON{
+				Name:    "test-server",
+				Version: "1.1.0",
 			},
-			responseBody: `{"servers": [], "metadata": {}}`,
-			expectNil:    true,
+			expectNil: false,
+		},
+		{
+			name:           "server not found",
+			searchName:     "nonexistent",
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"title": "Not Found", "status": 404, "detail": "Server not found"}`,
+			expectNil:      true,
 		},
 	}
 
@@ -666,11 +857,14 @@ func TestServersService_GetByNameLatest(t *testing.T) {
 			client, mux, _, teardown := setup()
 			defer teardown()
 
-			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+			mux.HandleFunc("/v0.1/servers/"+tt.searchName+"/versions", func(w http.ResponseWriter, r *http.Request) {
 				testMethod(t, r, "GET")
-				testFormValues(t, r, tt.expectedQuery)
+				testFormValues(t, r, values{})
 
 				w.Header().Set("Content-Type", "application/json")
+				if tt.responseStatus != 0 {
+					w.WriteHeader(tt.responseStatus)
+				}
 				fmt.Fprint(w, tt.responseBody)
 			})
 
@@ -844,7 +1038,7 @@ func TestServersService_GetByNameLatestActiveVersion(t *testing.T) {
 	tests := []struct {
 		name           string
 		searchName     string
-		expectedQuery  values
+		responseStatus int
 		responseBody   string
 		expectedResult *registryv0.ServerJSON
 		expectNil      bool
@@ -852,10 +1046,6 @@ func TestServersService_GetByNameLatestActiveVersion(t *testing.T) {
 		{
 			name:       "latest active version found",
 			searchName: "test-server",
-			expectedQuery: values{
-				"search": "test-server",
-				"limit":  "100",
-			},
 			responseBody: `{
 				"servers": [
 					{
@@ -883,10 +1073,6 @@ func TestServersService_GetByNameLatestActiveVersion(t *testing.T) {
 		{
 			name:       "no active versions",
 			searchName: "test-server",
-			expectedQuery: values{
-				"search": "test-server",
-				"limit":  "100",
-			},
 			responseBody: `{
 				"servers": [
 					{
@@ -903,22 +1089,15 @@ func TestServersService_GetByNameLatestActiveVersion(t *testing.T) {
 			expectNil: true,
 		},
 		{
-			name:       "server not found",
-			searchName: "nonexistent",
-			expectedQuery: values{
-				"search": "nonexistent",
-				"limit":  "100",
-			},
-			responseBody: `{"servers": [], "metadata": {}}`,
-			expectNil:    true,
+			name:           "server not found",
+			searchName:     "nonexistent",
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"title": "Not Found", "status": 404, "detail": "Server not found"}`,
+			expectNil:      true,
 		},
 		{
-			name:       "skip invalid semantic versions",
+			name:       "semantic versions rank above other versions",
 			searchName: "test-server",
-			expectedQuery: values{
-				"search": "test-server",
-				"limit":  "100",
-			},
 			responseBody: `{
 				"servers": [
 					{
@@ -946,10 +1125,6 @@ func TestServersService_GetByNameLatestActiveVersion(t *testing.T) {
 		{
 			name:       "filter by exact name match",
 			searchName: "exact-name",
-			expectedQuery: values{
-				"search": "exact-name",
-				"limit":  "100",
-			},
 			responseBody: `{
 				"servers": [
 					{
@@ -977,11 +1152,14 @@ func TestServersService_GetByNameLatestActiveVersion(t *testing.T) {
 			client, mux, _, teardown := setup()
 			defer teardown()
 
-			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+			mux.HandleFunc("/v0.1/servers/"+tt.searchName+"/versions", func(w http.ResponseWriter, r *http.Request) {
 				testMethod(t, r, "GET")
-				testFormValues(t, r, tt.expectedQuery)
+				testFormValues(t, r, values{})
 
 				w.Header().Set("Content-Type", "application/json")
+				if tt.responseStatus != 0 {
+					w.WriteHeader(tt.responseStatus)
+				}
 				fmt.Fprint(w, tt.responseBody)
 			})
 
@@ -1181,6 +1359,580 @@ func TestServersService_ListByUpdatedSince(t *testing.T) {
 	}
 }
 
+func TestServersService_WithMeta(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	const deprecated = `{
+		"server": {"name": "test/server", "version": "1.0.0"},
+		"_meta": {"io.modelcontextprotocol.registry/official": {
+			"status": "deprecated",
+			"publishedAt": "2024-01-01T00:00:00Z",
+			"updatedAt": "2024-02-01T00:00:00Z",
+			"isLatest": true
+		}}
+	}`
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"servers": [%s], "metadata": {}}`, deprecated)
+	})
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"servers": [%s], "metadata": {}}`, deprecated)
+	})
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, deprecated)
+	})
+
+	ctx := context.Background()
+	wantMeta := registryv0.RegistryExtensions{
+		Status:      model.StatusDeprecated,
+		PublishedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		IsLatest:    true,
+	}
+
+	tests := []struct {
+		name string
+		call func() ([]registryv0.ServerResponse, error)
+	}{
+		{
+			name: "GetWithMeta",
+			call: func() ([]registryv0.ServerResponse, error) {
+				server, _, err := client.Servers.GetWithMeta(ctx, "test/server", nil)
+				return []registryv0.ServerResponse{*server}, err
+			},
+		},
+		{
+			name: "ListVersionsByNameWithMeta",
+			call: func() ([]registryv0.ServerResponse, error) {
+				servers, _, err := client.Servers.ListVersionsByNameWithMeta(ctx, "test/server")
+				return servers, err
+			},
+		},
+		{
+			name: "ListAllWithMeta",
+			call: func() ([]registryv0.ServerResponse, error) {
+				servers, _, err := client.Servers.ListAllWithMeta(ctx, nil)
+				return servers, err
+			},
+		},
+		{
+			name: "ListByNameWithMeta",
+			call: func() ([]registryv0.ServerResponse, error) {
+				servers, _, err := client.Servers.ListByNameWithMeta(ctx, "test/server")
+				return servers, err
+			},
+		},
+		{
+			name: "GetByNameLatestWithMeta",
+			call: func() ([]registryv0.ServerResponse, error) {
+				server, _, err := client.Servers.GetByNameLatestWithMeta(ctx, "test/server")
+				return []registryv0.ServerResponse{*server}, err
+			},
+		},
+		{
+			name: "GetByNameExactVersionWithMeta",
+			call: func() ([]registryv0.ServerResponse, error) {
+				server, _, err := client.Servers.GetByNameExactVersionWithMeta(ctx, "test/server", "1.0.0")
+				return []registryv0.ServerResponse{*server}, err
+			},
+		},
+		{
+			name: "ListByUpdatedSinceWithMeta",
+			call: func() ([]registryv0.ServerResponse, error) {
+				servers, _, err := client.Servers.ListByUpdatedSinceWithMeta(ctx, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+				return servers, err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers, err := tt.call()
+			if err != nil {
+				t.Fatalf("%s returned error: %v", tt.name, err)
+			}
+			if len(servers) != 1 {
+				t.Fatalf("%s returned %d servers, want 1", tt.name, len(servers))
+			}
+			if servers[0].Server.Name != "test/server" {
+				t.Errorf("%s server name = %q, want %q", tt.name, servers[0].Server.Name, "test/server")
+			}
+			if servers[0].Meta.Official == nil {
+				t.Fatalf("%s dropped registry metadata", tt.name)
+			}
+			if !reflect.DeepEqual(*servers[0].Meta.Official, wantMeta) {
+				t.Errorf("%s metadata = %+v, want %+v", tt.name, *servers[0].Meta.Official, wantMeta)
+			}
+		})
+	}
+}
+
+func TestServersService_GetByNameLatestActiveVersionWithMeta(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"servers": [
+				{
+					"server": {"name": "test/server", "version": "2.0.0"},
+					"_meta": {"io.modelcontextprotocol.registry/official": {"status": "deprecated", "isLatest": true}}
+				},
+				{
+					"server": {"name": "test/server", "version": "1.5.0"},
+					"_meta": {"io.modelcontextprotocol.registry/official": {"status": "active", "publishedAt": "2024-01-01T00:00:00Z"}}
+				}
+			],
+			"metadata": {}
+		}`)
+	})
+
+	server, _, err := client.Servers.GetByNameLatestActiveVersionWithMeta(context.Background(), "test/server")
+	if err != nil {
+		t.Fatalf("Servers.GetByNameLatestActiveVersionWithMeta returned error: %v", err)
+	}
+	if server == nil {
+		t.Fatal("Servers.GetByNameLatestActiveVersionWithMeta returned nil")
+	}
+	if server.Server.Version != "1.5.0" {
+		t.Errorf("version = %q, want %q", server.Server.Version, "1.5.0")
+	}
+	if server.Meta.Official == nil || server.Meta.Official.Status != model.StatusActive {
+		t.Errorf("metadata = %+v, want active status", server.Meta.Official)
+	}
+}
+
+func TestServersService_Publish(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.SetAuthToken("registry-jwt")
+
+	server := &registryv0.ServerJSON{
+		Name:        "io.github.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+		Repository: model.Repository{
+			URL:    "https://github.com/example/test-server",
+			Source: "github",
+		},
+	}
+
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		if got := r.Header.Get("Authorization"); got != "Bearer registry-jwt" {
+			t.Errorf("Authorization header = %q, want %q", got, "Bearer registry-jwt")
+		}
+		if got := r.Header.Get("Content-Type"); got != mediaTypeJSON {
+			t.Errorf("Content-Type header = %q, want %q", got, mediaTypeJSON)
+		}
+
+		var body registryv0.ServerJSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		if !reflect.DeepEqual(&body, server) {
+			t.Errorf("request body = %+v, want %+v", body, *server)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"server": {
+				"name": "io.github.example/test-server",
+				"description": "A test server",
+				"version": "1.0.0",
+				"repository": {"url": "https://github.com/example/test-server", "source": "github"}
+			},
+			"_meta": {"io.modelcontextprotocol.registry/official": {
+				"status": "active",
+				"publishedAt": "2024-01-01T00:00:00Z",
+				"isLatest": true
+			}}
+		}`)
+	})
+
+	published, _, err := client.Servers.Publish(context.Background(), server)
+	if err != nil {
+		t.Fatalf("Servers.Publish returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(&published.Server, server) {
+		t.Errorf("Servers.Publish server = %+v, want %+v", published.Server, *server)
+	}
+	want := &registryv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		IsLatest:    true,
+	}
+	if !reflect.DeepEqual(published.Meta.Official, want) {
+		t.Errorf("Servers.Publish metadata = %+v, want %+v", published.Meta.Official, want)
+	}
+}
+
+func TestServersService_Publish_ValidationError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.SetAuthToken("registry-jwt")
+
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "validation failed",
+			"errors": [{"message": "expected length >= 1", "location": "body.description", "value": ""}]
+		}`)
+	})
+
+	_, _, err := client.Servers.Publish(context.Background(), &registryv0.ServerJSON{Name: "com.example/server", Version: "1.0.0"})
+	if !IsValidation(err) {
+		t.Fatalf("Servers.Publish error = %v, want IsValidation", err)
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Servers.Publish error type = %T, want *ErrorResponse", err)
+	}
+	if len(errResp.Errors) != 1 || errResp.Errors[0].Location != "body.description" {
+		t.Errorf("Servers.Publish error details = %+v, want location body.description", errResp.Errors)
+	}
+}
+
+func TestServersService_Publish_Unauthorized(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization header = %q, want none", got)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"title": "Unauthorized", "status": 401, "detail": "Invalid Authorization header format"}`)
+	})
+
+	_, _, err := client.Servers.Publish(context.Background(), &registryv0.ServerJSON{Name: "com.example/server"})
+	if !IsUnauthorized(err) {
+		t.Errorf("Servers.Publish error = %v, want IsUnauthorized", err)
+	}
+}
+
+func TestServersService_Publish_NilServer(t *testing.T) {
+	client := NewClient(nil)
+
+	if _, _, err := client.Servers.Publish(context.Background(), nil); err == nil {
+		t.Error("Servers.Publish(nil) expected error, got nil")
+	}
+}
+
+func TestServersService_Edit(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.SetAuthToken("admin-jwt")
+
+	server := &registryv0.ServerJSON{
+		Name:        "com.example/server",
+		Description: "Updated description",
+		Version:     "1.0.0",
+	}
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fserver/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testFormValues(t, r, values{"status": "deprecated"})
+
+		if got := r.Header.Get("Authorization"); got != "Bearer admin-jwt" {
+			t.Errorf("Authorization header = %q, want %q", got, "Bearer admin-jwt")
+		}
+
+		var body registryv0.ServerJSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		if !reflect.DeepEqual(&body, server) {
+			t.Errorf("request body = %+v, want %+v", body, *server)
+		}
+
+		fmt.Fprint(w, `{
+			"server": {"name": "com.example/server", "description": "Updated description", "version": "1.0.0"},
+			"_meta": {"io.modelcontextprotocol.registry/official": {"status": "deprecated", "isLatest": true}}
+		}`)
+	})
+
+	opts := &ServerEditOptions{Status: model.StatusDeprecated}
+	edited, _, err := client.Servers.Edit(context.Background(), "com.example/server", "1.0.0", server, opts)
+	if err != nil {
+		t.Fatalf("Servers.Edit returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(&edited.Server, server) {
+		t.Errorf("Servers.Edit server = %+v, want %+v", edited.Server, *server)
+	}
+	if edited.Meta.Official == nil || edited.Meta.Official.Status != model.StatusDeprecated {
+		t.Errorf("Servers.Edit meta = %+v, want status deprecated", edited.Meta.Official)
+	}
+}
+
+func TestServersService_Edit_InvalidArguments(t *testing.T) {
+	client := NewClient(nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		server     *registryv0.ServerJSON
+		wantErrMsg string
+	}{
+		{
+			name:       "nil server",
+			server:     nil,
+			wantErrMsg: "server must not be nil",
+		},
+		{
+			name:       "rename",
+			server:     &registryv0.ServerJSON{Name: "com.example/other", Version: "1.0.0"},
+			wantErrMsg: "servers cannot be renamed",
+		},
+		{
+			name:       "version mismatch",
+			server:     &registryv0.ServerJSON{Name: "com.example/server", Version: "2.0.0"},
+			wantErrMsg: `server version "2.0.0" does not match "1.0.0"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := client.Servers.Edit(ctx, "com.example/server", "1.0.0", tt.server, nil)
+			if err == nil {
+				t.Fatal("Servers.Edit expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErrMsg) {
+				t.Errorf("Servers.Edit error = %q, want to contain %q", err.Error(), tt.wantErrMsg)
+			}
+		})
+	}
+}
+
+// statusRegistryHandler serves a single version of com.example/server whose
+// status can be changed through the edit endpoint, enforcing the registry's
+// rule that deleted versions cannot be restored.
+func statusRegistryHandler(t *testing.T, status *model.Status) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var body registryv0.ServerJSON
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+			if body.Description != "A server" {
+				t.Errorf("request body description = %q, want the current server.json", body.Description)
+			}
+
+			newStatus := model.Status(r.URL.Query().Get("status"))
+			if *status == model.StatusDeleted && newStatus != model.StatusDeleted {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"title": "Bad Request", "status": 400, "detail": "Cannot change status of deleted server. Deleted servers cannot be undeleted."}`)
+				return
+			}
+			*status = newStatus
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+
+		fmt.Fprintf(w, `{
+			"server": {"name": "com.example/server", "description": "A server", "version": "1.0.0"},
+			"_meta": {"io.modelcontextprotocol.registry/official": {"status": %q}}
+		}`, *status)
+	}
+}
+
+func TestServersService_UpdateStatus(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	status := model.StatusActive
+	mux.HandleFunc("/v0.1/servers/com.example%2Fserver/versions/1.0.0", statusRegistryHandler(t, &status))
+
+	ctx := context.Background()
+	steps := []model.Status{model.StatusDeprecated, model.StatusActive, model.StatusDeleted}
+
+	for _, step := range steps {
+		updated, _, err := client.Servers.UpdateStatus(ctx, "com.example/server", "1.0.0", step)
+		if err != nil {
+			t.Fatalf("Servers.UpdateStatus(%s) returned error: %v", step, err)
+		}
+		if got := updated.Meta.Official.Status; got != step {
+			t.Errorf("Servers.UpdateStatus(%s) status = %s, want %s", step, got, step)
+		}
+	}
+
+	// Deleted versions cannot be restored.
+	_, _, err := client.Servers.UpdateStatus(ctx, "com.example/server", "1.0.0", model.StatusActive)
+	if !IsValidation(err) {
+		t.Errorf("Servers.UpdateStatus restoring a deleted version error = %v, want IsValidation", err)
+	}
+	if status != model.StatusDeleted {
+		t.Errorf("status after rejected restore = %s, want %s", status, model.StatusDeleted)
+	}
+}
+
+func TestServersService_UpdateStatus_Errors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmissing/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404, "detail": "Server not found"}`)
+	})
+
+	ctx := context.Background()
+
+	if _, _, err := client.Servers.UpdateStatus(ctx, "com.example/server", "1.0.0", "archived"); err == nil || !strings.Contains(err.Error(), `invalid status "archived"`) {
+		t.Errorf("Servers.UpdateStatus with invalid status error = %v, want invalid status", err)
+	}
+	if _, _, err := client.Servers.UpdateStatus(ctx, "com.example/missing", "1.0.0", model.StatusDeprecated); !IsNotFound(err) {
+		t.Errorf("Servers.UpdateStatus for missing version error = %v, want IsNotFound", err)
+	}
+}
+
+func TestServersService_Resolve(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	versions := []struct {
+		version string
+		status  model.Status
+	}{
+		{"0.9.0", model.StatusDeleted},
+		{"1.0.0", model.StatusActive},
+		{"1.2.0", model.StatusActive},
+		{"1.2.5", model.StatusDeprecated},
+		{"1.3.0-beta.1", model.StatusActive},
+		{"2.0.0", model.StatusActive},
+		{"2.0.3", model.StatusActive},
+		{"2.1.0", model.StatusActive},
+		{"nightly", model.StatusActive},
+		{"1.1", model.StatusActive}, // not a full major.minor.patch version
+	}
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fserver/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		var servers []string
+		for _, v := range versions {
+			servers = append(servers, fmt.Sprintf(`{
+				"server": {"name": "com.example/server", "version": %q},
+				"_meta": {"io.modelcontextprotocol.registry/official": {"status": %q}}
+			}`, v.version, v.status))
+		}
+		fmt.Fprintf(w, `{"servers": [%s], "metadata": {"count": %d}}`, strings.Join(servers, ","), len(servers))
+	})
+
+	tests := []struct {
+		name           string
+		constraint     string
+		opts           *ResolveOptions
+		wantCandidates []string
+	}{
+		{
+			name:           "caret",
+			constraint:     "^1.2",
+			wantCandidates: []string{"1.2.0"},
+		},
+		{
+			name:           "caret including deprecated",
+			constraint:     "^1.2",
+			opts:           &ResolveOptions{IncludeDeprecated: true},
+			wantCandidates: []string{"1.2.5", "1.2.0"},
+		},
+		{
+			name:           "caret including prerelease",
+			constraint:     "^1.2",
+			opts:           &ResolveOptions{IncludePrerelease: true},
+			wantCandidates: []string{"1.3.0-beta.1", "1.2.0"},
+		},
+		{
+			name:           "tilde",
+			constraint:     "~2.0.3",
+			wantCandidates: []string{"2.0.3"},
+		},
+		{
+			name:           "range",
+			constraint:     ">=1.0 <2",
+			wantCandidates: []string{"1.2.0", "1.0.0"},
+		},
+		{
+			name:           "any version",
+			constraint:     "",
+			wantCandidates: []string{"2.1.0", "2.0.3", "2.0.0", "1.2.0", "1.0.0"},
+		},
+		{
+			name:           "deleted versions never match",
+			constraint:     "<1",
+			opts:           &ResolveOptions{IncludeDeprecated: true},
+			wantCandidates: nil,
+		},
+		{
+			name:           "no match",
+			constraint:     "^3",
+			wantCandidates: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution, _, err := client.Servers.Resolve(context.Background(), "com.example/server", tt.constraint, tt.opts)
+			if err != nil {
+				t.Fatalf("Servers.Resolve returned error: %v", err)
+			}
+
+			var got []string
+			for _, c := range resolution.Candidates {
+				got = append(got, c.Server.Version)
+			}
+			if !reflect.DeepEqual(got, tt.wantCandidates) {
+				t.Errorf("Servers.Resolve candidates = %v, want %v", got, tt.wantCandidates)
+			}
+
+			switch {
+			case len(tt.wantCandidates) == 0 && resolution.Match != nil:
+				t.Errorf("Servers.Resolve match = %s, want nil", resolution.Match.Server.Version)
+			case len(tt.wantCandidates) > 0 && (resolution.Match == nil || resolution.Match.Server.Version != tt.wantCandidates[0]):
+				t.Errorf("Servers.Resolve match = %+v, want %s", resolution.Match, tt.wantCandidates[0])
+			}
+		})
+	}
+}
+
+func TestServersService_Resolve_Errors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmissing/versions", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404, "detail": "Server not found"}`)
+	})
+
+	ctx := context.Background()
+
+	if _, _, err := client.Servers.Resolve(ctx, "com.example/missing", "not a constraint", nil); err == nil || !strings.Contains(err.Error(), "invalid version constraint") {
+		t.Errorf("Servers.Resolve with invalid constraint error = %v, want invalid version constraint", err)
+	}
+	if requests != 0 {
+		t.Errorf("Servers.Resolve with invalid constraint made %d requests, want 0", requests)
+	}
+
+	if _, _, err := client.Servers.Resolve(ctx, "com.example/missing", "^1", nil); !IsNotFound(err) {
+		t.Errorf("Servers.Resolve for missing server error = %v, want IsNotFound", err)
+	}
+}
+
 func TestServersService_Get_NilResponse(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -1238,12 +1990,9 @@ func TestServersService_GetByNameLatestActiveVersion_NoOfficialMeta(t *testing.T
 	client, mux, _, teardown := setup()
 	defer teardown()
 
-	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/v0.1/servers/test-server/versions", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "GET")
-		testFormValues(t, r, values{
-			"search": "test-server",
-			"limit":  "100",
-		})
+		testFormValues(t, r, values{})
 
 		w.Header().Set("Content-Type", "application/json")
 		// Return servers without official metadata
@@ -1274,6 +2023,132 @@ func TestServersService_GetByNameLatestActiveVersion_NoOfficialMeta(t *testing.T
 	}
 }
 
+func TestServersService_GetByNameLatest_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/test-server/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"title": "Internal Server Error", "status": 500}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Servers.GetByNameLatest(ctx, "test-server"); err == nil {
+		t.Error("Servers.GetByNameLatest expected error, got nil")
+	}
+	if _, _, err := client.Servers.GetByNameLatestActiveVersion(ctx, "test-server"); err == nil {
+		t.Error("Servers.GetByNameLatestActiveVersion expected error, got nil")
+	}
+	if _, _, err := client.Servers.ListByName(ctx, "test-server"); err == nil {
+		t.Error("Servers.ListByName expected error, got nil")
+	}
+}
+
+// nearNameRegistry serves a registry holding a server and thousands of
+// servers whose names contain its name, counting the requests it receives.
+func nearNameRegistry(b *testing.B, name string, nearNames int) (*Client, *atomic.Int64, func()) {
+	b.Helper()
+
+	var servers []registryv0.ServerResponse
+	for i := range nearNames {
+		servers = append(servers, registryv0.ServerResponse{
+			Server: registryv0.ServerJSON{Name: fmt.Sprintf("%s-%d", name, i), Version: "1.0.0"},
+			Meta:   registryv0.ResponseMeta{Official: &registryv0.RegistryExtensions{Status: model.StatusActive}},
+		})
+	}
+	var versions []registryv0.ServerResponse
+	for _, version := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+		versions = append(versions, registryv0.ServerResponse{
+			Server: registryv0.ServerJSON{Name: name, Version: version},
+			Meta:   registryv0.ResponseMeta{Official: &registryv0.RegistryExtensions{Status: model.StatusActive}},
+		})
+	}
+	// The registry orders search results by name, so the server itself
+	// sorts after most of its near matches.
+	servers = append(servers, versions...)
+
+	var requests atomic.Int64
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		query := r.URL.Query()
+		var matches []registryv0.ServerResponse
+		for _, server := range servers {
+			if strings.Contains(server.Server.Name, query.Get("search")) {
+				matches = append(matches, server)
+			}
+		}
+
+		offset, _ := strconv.Atoi(query.Get("cursor"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		end := min(offset+limit, len(matches))
+
+		page := registryv0.ServerListResponse{Servers: matches[offset:end]}
+		if end < len(matches) {
+			page.Metadata.NextCursor = strconv.Itoa(end)
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+	mux.HandleFunc("/v0.1/servers/"+url.PathEscape(name)+"/versions", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		json.NewEncoder(w).Encode(registryv0.ServerListResponse{Servers: versions})
+	})
+
+	server := httptest.NewServer(mux)
+	client := NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client, &requests, server.Close
+}
+
+func BenchmarkServersService_GetByNameLatestActiveVersion(b *testing.B) {
+	const name = "io.github.example/github"
+
+	ctx := context.Background()
+
+	b.Run("search", func(b *testing.B) {
+		client, requests, teardown := nearNameRegistry(b, name, 5000)
+		defer teardown()
+
+		// The previous implementation: page through substring search
+		// results and filter on the exact name client-side.
+		opts := &ServerListOptions{Search: name, ListOptions: ListOptions{Limit: 100}}
+		for b.Loop() {
+			servers, _, err := client.Servers.ListAllWithMeta(ctx, opts)
+			if err != nil {
+				b.Fatal(err)
+			}
+			var latest *registryv0.ServerResponse
+			for i, server := range servers {
+				if server.Server.Name == name && (latest == nil || CompareVersions(server, *latest) > 0) {
+					latest = &servers[i]
+				}
+			}
+			if latest == nil || latest.Server.Version != "2.0.0" {
+				b.Fatalf("latest = %+v, want 2.0.0", latest)
+			}
+		}
+		b.ReportMetric(float64(requests.Load())/float64(b.N), "requests/op")
+	})
+
+	b.Run("versions", func(b *testing.B) {
+		client, requests, teardown := nearNameRegistry(b, name, 5000)
+		defer teardown()
+
+		for b.Loop() {
+			server, _, err := client.Servers.GetByNameLatestActiveVersion(ctx, name)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if server == nil || server.Version != "2.0.0" {
+				b.Fatalf("latest = %+v, want 2.0.0", server)
+			}
+		}
+		b.ReportMetric(float64(requests.Load())/float64(b.N), "requests/op")
+	})
+}
+
 // Test helper functions
 
 func setup() (client *Client, mux *http.ServeMux, serverURL string, teardown func()) {
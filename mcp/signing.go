@@ -0,0 +1,252 @@
+package mcp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Key algorithms accepted by the registry's DNS and HTTP authentication
+// methods, as they appear in the k= field of a public key record.
+const (
+	KeyAlgorithmEd25519   = "ed25519"
+	KeyAlgorithmECDSAP384 = "ecdsap384"
+)
+
+// WellKnownAuthPath is the path, relative to https://<domain>, at which the
+// registry fetches the public key record for HTTP authentication.
+const WellKnownAuthPath = "/.well-known/mcp-registry-auth"
+
+// p384ScalarSize is the size in bytes of a P-384 private scalar and of each
+// half of an r||s signature.
+const p384ScalarSize = 48
+
+// Signer signs timestamps for the registry's DNS and HTTP authentication
+// methods. Use ParsePrivateKeyPEM, ParsePrivateKeyHex, NewEd25519Signer or
+// NewECDSAP384Signer to create one.
+type Signer interface {
+	// Algorithm returns the key algorithm, KeyAlgorithmEd25519 or
+	// KeyAlgorithmECDSAP384.
+	Algorithm() string
+
+	// PublicKey returns the public key in the encoding published in the
+	// p= field of the public key record.
+	PublicKey() []byte
+
+	// Sign returns the signature of message in the encoding expected by the
+	// registry.
+	Sign(message []byte) ([]byte, error)
+}
+
+type ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519Signer returns a Signer for an Ed25519 private key.
+func NewEd25519Signer(key ed25519.PrivateKey) (Signer, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 private key length: expected %d, got %d", ed25519.PrivateKeySize, len(key))
+	}
+	return &ed25519Signer{key: key}, nil
+}
+
+func (s *ed25519Signer) Algorithm() string { return KeyAlgorithmEd25519 }
+
+func (s *ed25519Signer) PublicKey() []byte {
+	return []byte(s.key.Public().(ed25519.PublicKey))
+}
+
+func (s *ed25519Signer) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, message), nil
+}
+
+type ecdsaP384Signer struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewECDSAP384Signer returns a Signer for an ECDSA private key on the P-384
+// curve. Signatures are computed over the SHA-384 digest of the message and
+// encoded as the 96-byte concatenation of r and s. Registries that predate
+// ECDSA support only accept Ed25519 keys.
+func NewECDSAP384Signer(key *ecdsa.PrivateKey) (Signer, error) {
+	if key == nil {
+		return nil, errors.New("ECDSA private key must not be nil")
+	}
+	if key.Curve != elliptic.P384() {
+		return nil, fmt.Errorf("unsupported ECDSA curve %s: only P-384 is supported", key.Curve.Params().Name)
+	}
+	return &ecdsaP384Signer{key: key}, nil
+}
+
+func (s *ecdsaP384Signer) Algorithm() string { return KeyAlgorithmECDSAP384 }
+
+// PublicKey returns the public key in SEC 1 compressed form.
+func (s *ecdsaP384Signer) PublicKey() []byte {
+	uncompressed, err := s.key.PublicKey.Bytes()
+	if err != nil {
+		return nil
+	}
+
+	// An uncompressed point is 0x04 || X || Y; the compressed form keeps X and
+	// encodes the parity of Y in the prefix.
+	compressed := make([]byte, 1+p384ScalarSize)
+	compressed[0] = 0x02 | uncompressed[len(uncompressed)-1]&1
+	copy(compressed[1:], uncompressed[1:1+p384ScalarSize])
+	return compressed
+}
+
+func (s *ecdsaP384Signer) Sign(message []byte) ([]byte, error) {
+	digest := sha512.Sum384(message)
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	signature := make([]byte, 2*p384ScalarSize)
+	r.FillBytes(signature[:p384ScalarSize])
+	sig.FillBytes(signature[p384ScalarSize:])
+	return signature, nil
+}
+
+// ParsePrivateKeyPEM returns a Signer for a PEM-encoded private key, such as
+// one generated with:
+//
+//	openssl genpkey -algorithm Ed25519 -out key.pem
+//	openssl ecparam -name secp384r1 -genkey -noout -out key.pem
+//
+// PKCS #8 ("PRIVATE KEY") and SEC 1 ("EC PRIVATE KEY") blocks are supported.
+func ParsePrivateKeyPEM(data []byte) (Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found in private key")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PKCS #8 private key: %w", err)
+		}
+		switch key := key.(type) {
+		case ed25519.PrivateKey:
+			return NewEd25519Signer(key)
+		case *ecdsa.PrivateKey:
+			return NewECDSAP384Signer(key)
+		default:
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing EC private key: %w", err)
+		}
+		return NewECDSAP384Signer(key)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// ParsePrivateKeyHex returns a Signer for a hex-encoded private key, the
+// format accepted by the upstream mcp-publisher's --private-key flag. The
+// algorithm is inferred from the decoded length: a 32-byte Ed25519 seed, a
+// 64-byte Ed25519 private key (seed followed by public key), or a 48-byte
+// P-384 private scalar.
+func ParsePrivateKeyHex(hexKey string) (Signer, error) {
+	b, err := hex.DecodeString(strings.TrimSpace(hexKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex private key: %w", err)
+	}
+
+	switch len(b) {
+	case ed25519.SeedSize:
+		return NewEd25519Signer(ed25519.NewKeyFromSeed(b))
+	case ed25519.PrivateKeySize:
+		return NewEd25519Signer(ed25519.PrivateKey(b))
+	case p384ScalarSize:
+		key, err := ecdsa.ParseRawPrivateKey(elliptic.P384(), b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid P-384 private key: %w", err)
+		}
+		return NewECDSAP384Signer(key)
+	default:
+		return nil, fmt.Errorf("invalid private key length %d: expected %d or %d bytes for Ed25519, or %d bytes for P-384",
+			len(b), ed25519.SeedSize, ed25519.PrivateKeySize, p384ScalarSize)
+	}
+}
+
+// SignTimestamp signs the RFC3339 encoding of t with signer and returns the
+// request body for ExchangeDNS or ExchangeHTTP. The registry rejects
+// timestamps more than 15 seconds from its own clock, so t should be the
+// current time.
+func SignTimestamp(domain string, signer Signer, t time.Time) (*SignatureTokenExchangeRequest, error) {
+	if signer == nil {
+		return nil, errors.New("signer must not be nil")
+	}
+
+	timestamp := t.UTC().Format(time.RFC3339)
+	signature, err := signer.Sign([]byte(timestamp))
+	if err != nil {
+		return nil, fmt.Errorf("signing timestamp: %w", err)
+	}
+
+	return &SignatureTokenExchangeRequest{
+		Domain:          domain,
+		Timestamp:       timestamp,
+		SignedTimestamp: hex.EncodeToString(signature),
+	}, nil
+}
+
+// PublicKeyRecord returns the public key record for signer, in the form
+// "v=MCPv1; k=<algorithm>; p=<base64 public key>". It is the value of the
+// DNS TXT record for DNS authentication and the content of the well-known
+// file for HTTP authentication.
+func PublicKeyRecord(signer Signer) string {
+	return fmt.Sprintf("v=MCPv1; k=%s; p=%s", signer.Algorithm(), base64.StdEncoding.EncodeToString(signer.PublicKey()))
+}
+
+// DNSTXTRecord returns the zone file entry publishing signer's public key
+// record as a TXT record on domain, for use with ExchangeDNS.
+func DNSTXTRecord(domain string, signer Signer) string {
+	return fmt.Sprintf("%s. IN TXT %q", strings.TrimSuffix(domain, "."), PublicKeyRecord(signer))
+}
+
+// WellKnownAuthFile returns the content to serve at
+// https://<domain>/.well-known/mcp-registry-auth for use with ExchangeHTTP.
+func WellKnownAuthFile(signer Signer) string {
+	return PublicKeyRecord(signer) + "\n"
+}
+
+// ExchangeDNSWithSigner signs the current time with signer and exchanges it
+// for a registry JWT using DNS authentication. domain must publish the
+// record returned by DNSTXTRecord.
+func (s *AuthService) ExchangeDNSWithSigner(ctx context.Context, domain string, signer Signer) (*TokenResponse, *Response, error) {
+	body, err := SignTimestamp(domain, signer, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.ExchangeDNS(ctx, body)
+}
+
+// ExchangeHTTPWithSigner signs the current time with signer and exchanges it
+// for a registry JWT using HTTP authentication. domain must serve the file
+// returned by WellKnownAuthFile at WellKnownAuthPath.
+func (s *AuthService) ExchangeHTTPWithSigner(ctx context.Context, domain string, signer Signer) (*TokenResponse, *Response, error) {
+	body, err := SignTimestamp(domain, signer, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.ExchangeHTTP(ctx, body)
+}
@@ -0,0 +1,322 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// verifySignature checks signature against a public key record the way the
+// registry does.
+func verifySignature(t *testing.T, record string, message, signature []byte) bool {
+	t.Helper()
+
+	m := regexp.MustCompile(`^v=MCPv1; k=(ed25519|ecdsap384); p=([A-Za-z0-9+/=]+)$`).FindStringSubmatch(record)
+	if m == nil {
+		t.Fatalf("malformed public key record %q", record)
+	}
+	pub, err := base64.StdEncoding.DecodeString(m[2])
+	if err != nil {
+		t.Fatalf("decoding public key: %v", err)
+	}
+
+	switch m[1] {
+	case KeyAlgorithmEd25519:
+		return len(pub) == ed25519.PublicKeySize && ed25519.Verify(pub, message, signature)
+	default:
+		x, y := elliptic.UnmarshalCompressed(elliptic.P384(), pub)
+		if x == nil || len(signature) != 96 {
+			return false
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P384(), X: x, Y: y}
+		digest := sha512.Sum384(message)
+		r := new(big.Int).SetBytes(signature[:48])
+		s := new(big.Int).SetBytes(signature[48:])
+		return ecdsa.Verify(key, digest[:], r, s)
+	}
+}
+
+func pemEncode(t *testing.T, blockType string, der []byte) []byte {
+	t.Helper()
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPKCS8, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPKCS8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecSEC1, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecRaw, err := ecKey.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		parse         func() (Signer, error)
+		wantAlgorithm string
+		wantPublicKey []byte
+	}{
+		{
+			name:          "Ed25519 PKCS #8 PEM",
+			parse:         func() (Signer, error) { return ParsePrivateKeyPEM(pemEncode(t, "PRIVATE KEY", edPKCS8)) },
+			wantAlgorithm: KeyAlgorithmEd25519,
+			wantPublicKey: edKey.Public().(ed25519.PublicKey),
+		},
+		{
+			name:          "Ed25519 hex seed",
+			parse:         func() (Signer, error) { return ParsePrivateKeyHex(hex.EncodeToString(edKey.Seed())) },
+			wantAlgorithm: KeyAlgorithmEd25519,
+			wantPublicKey: edKey.Public().(ed25519.PublicKey),
+		},
+		{
+			name:          "Ed25519 hex private key",
+			parse:         func() (Signer, error) { return ParsePrivateKeyHex(hex.EncodeToString(edKey) + "\n") },
+			wantAlgorithm: KeyAlgorithmEd25519,
+			wantPublicKey: edKey.Public().(ed25519.PublicKey),
+		},
+		{
+			name:          "P-384 PKCS #8 PEM",
+			parse:         func() (Signer, error) { return ParsePrivateKeyPEM(pemEncode(t, "PRIVATE KEY", ecPKCS8)) },
+			wantAlgorithm: KeyAlgorithmECDSAP384,
+			wantPublicKey: elliptic.MarshalCompressed(elliptic.P384(), ecKey.X, ecKey.Y),
+		},
+		{
+			name:          "P-384 SEC 1 PEM",
+			parse:         func() (Signer, error) { return ParsePrivateKeyPEM(pemEncode(t, "EC PRIVATE KEY", ecSEC1)) },
+			wantAlgorithm: KeyAlgorithmECDSAP384,
+			wantPublicKey: elliptic.MarshalCompressed(elliptic.P384(), ecKey.X, ecKey.Y),
+		},
+		{
+			name:          "P-384 hex scalar",
+			parse:         func() (Signer, error) { return ParsePrivateKeyHex(hex.EncodeToString(ecRaw)) },
+			wantAlgorithm: KeyAlgorithmECDSAP384,
+			wantPublicKey: elliptic.MarshalCompressed(elliptic.P384(), ecKey.X, ecKey.Y),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := tt.parse()
+			if err != nil {
+				t.Fatalf("parse returned error: %v", err)
+			}
+
+			if got := signer.Algorithm(); got != tt.wantAlgorithm {
+				t.Errorf("Algorithm() = %q, want %q", got, tt.wantAlgorithm)
+			}
+			if got := signer.PublicKey(); !bytes.Equal(got, tt.wantPublicKey) {
+				t.Errorf("PublicKey() = %x, want %x", got, tt.wantPublicKey)
+			}
+
+			message := []byte("2024-01-01T00:00:00Z")
+			signature, err := signer.Sign(message)
+			if err != nil {
+				t.Fatalf("Sign returned error: %v", err)
+			}
+			if !verifySignature(t, PublicKeyRecord(signer), message, signature) {
+				t.Error("signature does not verify against the public key record")
+			}
+		})
+	}
+}
+
+func TestParsePrivateKey_Errors(t *testing.T) {
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256SEC1, err := x509.MarshalECPrivateKey(p256Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		parse      func() (Signer, error)
+		wantErrMsg string
+	}{
+		{
+			name:       "not PEM",
+			parse:      func() (Signer, error) { return ParsePrivateKeyPEM([]byte("not a key")) },
+			wantErrMsg: "no PEM block found",
+		},
+		{
+			name:       "unsupported PEM type",
+			parse:      func() (Signer, error) { return ParsePrivateKeyPEM(pemEncode(t, "RSA PRIVATE KEY", []byte{0})) },
+			wantErrMsg: `unsupported PEM block type "RSA PRIVATE KEY"`,
+		},
+		{
+			name:       "malformed PKCS #8",
+			parse:      func() (Signer, error) { return ParsePrivateKeyPEM(pemEncode(t, "PRIVATE KEY", []byte{0})) },
+			wantErrMsg: "parsing PKCS #8 private key",
+		},
+		{
+			name:       "unsupported curve",
+			parse:      func() (Signer, error) { return ParsePrivateKeyPEM(pemEncode(t, "EC PRIVATE KEY", p256SEC1)) },
+			wantErrMsg: "unsupported ECDSA curve P-256",
+		},
+		{
+			name:       "invalid hex",
+			parse:      func() (Signer, error) { return ParsePrivateKeyHex("zz") },
+			wantErrMsg: "invalid hex private key",
+		},
+		{
+			name:       "invalid hex length",
+			parse:      func() (Signer, error) { return ParsePrivateKeyHex(strings.Repeat("ab", 16)) },
+			wantErrMsg: "invalid private key length 16",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := tt.parse()
+			if err == nil {
+				t.Fatalf("parse returned signer %v, want error", signer)
+			}
+			if !strings.Contains(err.Error(), tt.wantErrMsg) {
+				t.Errorf("error = %q, want to contain %q", err.Error(), tt.wantErrMsg)
+			}
+		})
+	}
+}
+
+func TestSignTimestamp(t *testing.T) {
+	signer, err := ParsePrivateKeyHex(strings.Repeat("01", ed25519.SeedSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+	body, err := SignTimestamp("example.com", signer, ts)
+	if err != nil {
+		t.Fatalf("SignTimestamp returned error: %v", err)
+	}
+
+	if body.Domain != "example.com" {
+		t.Errorf("Domain = %q, want %q", body.Domain, "example.com")
+	}
+	if want := "2024-01-01T17:00:00Z"; body.Timestamp != want {
+		t.Errorf("Timestamp = %q, want %q", body.Timestamp, want)
+	}
+
+	signature, err := hex.DecodeString(body.SignedTimestamp)
+	if err != nil {
+		t.Fatalf("SignedTimestamp is not hex: %v", err)
+	}
+	if !verifySignature(t, PublicKeyRecord(signer), []byte(body.Timestamp), signature) {
+		t.Error("SignedTimestamp does not verify")
+	}
+
+	if _, err := SignTimestamp("example.com", nil, ts); err == nil {
+		t.Error("SignTimestamp with nil signer expected error, got nil")
+	}
+}
+
+func TestPublicKeyRecords(t *testing.T) {
+	signer, err := ParsePrivateKeyHex(strings.Repeat("01", ed25519.SeedSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := base64.StdEncoding.EncodeToString(signer.PublicKey())
+
+	if got, want := PublicKeyRecord(signer), "v=MCPv1; k=ed25519; p="+p; got != want {
+		t.Errorf("PublicKeyRecord() = %q, want %q", got, want)
+	}
+	if got, want := DNSTXTRecord("example.com.", signer), `example.com. IN TXT "v=MCPv1; k=ed25519; p=`+p+`"`; got != want {
+		t.Errorf("DNSTXTRecord() = %q, want %q", got, want)
+	}
+	if got, want := WellKnownAuthFile(signer), "v=MCPv1; k=ed25519; p="+p+"\n"; got != want {
+		t.Errorf("WellKnownAuthFile() = %q, want %q", got, want)
+	}
+}
+
+func TestAuthService_ExchangeWithSigner(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	signer, err := ParsePrivateKeyHex(strings.Repeat("02", ed25519.SeedSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := PublicKeyRecord(signer)
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body SignatureTokenExchangeRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		if body.Domain != "example.com" {
+			t.Errorf("domain = %q, want %q", body.Domain, "example.com")
+		}
+
+		ts, err := time.Parse(time.RFC3339, body.Timestamp)
+		if err != nil || time.Since(ts).Abs() > 15*time.Second {
+			t.Errorf("timestamp %q outside the registry's window", body.Timestamp)
+		}
+
+		signature, _ := hex.DecodeString(body.SignedTimestamp)
+		if !verifySignature(t, record, []byte(body.Timestamp), signature) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"title": "Unauthorized", "status": 401, "detail": "signature verification failed"}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"registry_token": "domain-jwt", "expires_at": 0}`)
+	}
+	mux.HandleFunc("/v0.1/auth/dns", handler)
+	mux.HandleFunc("/v0.1/auth/http", handler)
+
+	ctx := context.Background()
+
+	token, _, err := client.Auth.ExchangeDNSWithSigner(ctx, "example.com", signer)
+	if err != nil {
+		t.Fatalf("Auth.ExchangeDNSWithSigner returned error: %v", err)
+	}
+	if token.RegistryToken != "domain-jwt" {
+		t.Errorf("RegistryToken = %q, want %q", token.RegistryToken, "domain-jwt")
+	}
+
+	if _, _, err := client.Auth.ExchangeHTTPWithSigner(ctx, "example.com", signer); err != nil {
+		t.Fatalf("Auth.ExchangeHTTPWithSigner returned error: %v", err)
+	}
+
+	if _, _, err := client.Auth.ExchangeDNSWithSigner(ctx, "example.com", nil); err == nil {
+		t.Error("Auth.ExchangeDNSWithSigner with nil signer expected error, got nil")
+	}
+}
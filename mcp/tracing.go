@@ -0,0 +1,217 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Tracer starts spans for the operations performed by a Client, so that SDK
+// calls appear in distributed traces. Implementations must be safe for
+// concurrent use; adapting an OpenTelemetry tracer takes a few lines.
+//
+// The Client starts a span for every request sent by Do, named after its
+// method and route template, such as "GET v0.1/servers/{name}/versions", and
+// a parent span for operations made of several requests, such as
+// "Servers.ListAll" with one child span per page. The SpanContext of the
+// request span is sent to the API in a W3C traceparent header.
+type Tracer interface {
+	// Start starts a span named name as a child of the span in ctx, if any,
+	// and returns a context holding the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single operation within a trace.
+type Span interface {
+	// SpanContext returns the identity of the span. A span with an invalid
+	// SpanContext is not propagated to the API.
+	SpanContext() SpanContext
+
+	// SetAttribute records a key-value attribute describing the operation.
+	SetAttribute(key string, value any)
+
+	// RecordError records that the operation failed with err.
+	RecordError(err error)
+
+	// End marks the operation as complete.
+	End()
+}
+
+// SpanContext identifies a span within a trace, as propagated by the W3C
+// Trace Context traceparent header.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid reports whether the trace and span IDs are both non-zero.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// TraceParent returns sc formatted as a version 00 traceparent header value.
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), flags)
+}
+
+// NoopTracer is a Tracer whose spans record nothing and are not propagated.
+// It is used when a Client has no Tracer.
+type NoopTracer struct{}
+
+// Start returns ctx unchanged and a span that records nothing.
+func (NoopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SpanContext() SpanContext       { return SpanContext{} }
+func (noopSpan) SetAttribute(key string, v any) {}
+func (noopSpan) RecordError(err error)          {}
+func (noopSpan) End()                           {}
+
+// startSpan starts a span named name using the Client's Tracer.
+func (c *Client) startSpan(ctx context.Context, name string) (context.Context, Span) {
+	if c.Tracer == nil {
+		return NoopTracer{}.Start(ctx, name)
+	}
+	return c.Tracer.Start(ctx, name)
+}
+
+// endSpan records err, if any, on span and ends it.
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// injectTraceParent returns req carrying the traceparent header of span, or
+// req itself if the span is not propagated. The caller's request headers are
+// not modified.
+func injectTraceParent(req *http.Request, span Span) *http.Request {
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return req
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("traceparent", sc.TraceParent())
+	return req
+}
+
+// SpanRecorder is a Tracer keeping every span it starts in memory, for use
+// in tests. It is safe for concurrent use.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+// NewSpanRecorder returns an empty SpanRecorder.
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+// RecordedSpan is a span started by a SpanRecorder.
+type RecordedSpan struct {
+	Name    string
+	Context SpanContext
+
+	// Parent is the SpanContext of the parent span, invalid for root spans.
+	Parent SpanContext
+
+	Attributes map[string]any
+	Err        error
+	StartTime  time.Time
+	EndTime    time.Time // zero while the span is in progress
+
+	recorder *SpanRecorder
+}
+
+type recordedSpanKey struct{}
+
+// Start starts a span as a child of the SpanRecorder span in ctx, if any.
+func (r *SpanRecorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &RecordedSpan{
+		Name:       name,
+		Attributes: make(map[string]any),
+		StartTime:  time.Now(),
+		recorder:   r,
+	}
+
+	if parent, ok := ctx.Value(recordedSpanKey{}).(*RecordedSpan); ok {
+		span.Parent = parent.Context
+		span.Context.TraceID = parent.Context.TraceID
+	} else {
+		rand.Read(span.Context.TraceID[:])
+	}
+	rand.Read(span.Context.SpanID[:])
+	span.Context.Sampled = true
+
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+
+	return context.WithValue(ctx, recordedSpanKey{}, span), span
+}
+
+// Spans returns a copy of the spans started so far, in the order they were
+// started.
+func (r *SpanRecorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spans := make([]RecordedSpan, len(r.spans))
+	for i, span := range r.spans {
+		spans[i] = *span
+		spans[i].Attributes = maps.Clone(span.Attributes)
+		spans[i].recorder = nil
+	}
+	return spans
+}
+
+// Reset discards the spans recorded so far.
+func (r *SpanRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.spans = nil
+}
+
+// SpanContext returns the identity of the span.
+func (s *RecordedSpan) SpanContext() SpanContext {
+	return s.Context
+}
+
+// SetAttribute records an attribute on the span.
+func (s *RecordedSpan) SetAttribute(key string, value any) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+
+	s.Attributes[key] = value
+}
+
+// RecordError records err on the span.
+func (s *RecordedSpan) RecordError(err error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+
+	s.Err = err
+}
+
+// End records the time the span ended.
+func (s *RecordedSpan) End() {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+
+	s.EndTime = time.Now()
+}
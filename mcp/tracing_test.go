@@ -0,0 +1,240 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestSpanContext_TraceParent(t *testing.T) {
+	sc := SpanContext{
+		TraceID: [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		Sampled: true,
+	}
+
+	want := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	if got := sc.TraceParent(); got != want {
+		t.Errorf("TraceParent() = %q, want %q", got, want)
+	}
+
+	sc.Sampled = false
+	if got := sc.TraceParent(); got[len(got)-2:] != "00" {
+		t.Errorf("TraceParent() = %q, want flags 00 when not sampled", got)
+	}
+
+	if !sc.IsValid() {
+		t.Error("IsValid() = false, want true")
+	}
+	if (SpanContext{}).IsValid() {
+		t.Error("zero SpanContext IsValid() = true, want false")
+	}
+}
+
+func TestClient_Tracer_ListAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	recorder := NewSpanRecorder()
+	client.Tracer = recorder
+
+	var mu sync.Mutex
+	var headers []string
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Get("traceparent"))
+		mu.Unlock()
+
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"servers": [{"server": {"name": "a/one", "version": "1.0.0"}}], "metadata": {"nextCursor": "page2"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"servers": [{"server": {"name": "a/two", "version": "1.0.0"}}], "metadata": {}}`)
+	})
+
+	servers, _, err := client.Servers.ListAll(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(servers) != 2 {
+		t.Fatalf("ListAll returned %d servers, want 2", len(servers))
+	}
+
+	spans := recorder.Spans()
+	if len(spans) != 3 {
+		t.Fatalf("recorded %d spans, want 3: %+v", len(spans), spans)
+	}
+
+	parent := spans[0]
+	if parent.Name != "Servers.ListAll" {
+		t.Errorf("parent span name = %q, want %q", parent.Name, "Servers.ListAll")
+	}
+	if parent.Parent.IsValid() {
+		t.Error("parent span has a parent, want a root span")
+	}
+	if parent.EndTime.IsZero() {
+		t.Error("parent span was not ended")
+	}
+	if got := parent.Attributes["mcp.servers.count"]; got != 2 {
+		t.Errorf("mcp.servers.count = %v, want 2", got)
+	}
+
+	traceParent := regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`)
+	for i, child := range spans[1:] {
+		if child.Name != "GET v0.1/servers" {
+			t.Errorf("child %d name = %q, want %q", i, child.Name, "GET v0.1/servers")
+		}
+		if child.Parent != parent.Context {
+			t.Errorf("child %d parent = %+v, want %+v", i, child.Parent, parent.Context)
+		}
+		if child.Context.TraceID != parent.Context.TraceID {
+			t.Errorf("child %d is in trace %x, want %x", i, child.Context.TraceID, parent.Context.TraceID)
+		}
+		if child.EndTime.IsZero() {
+			t.Errorf("child %d was not ended", i)
+		}
+		if got := child.Attributes["http.response.status_code"]; got != http.StatusOK {
+			t.Errorf("child %d http.response.status_code = %v, want 200", i, got)
+		}
+		if !traceParent.MatchString(headers[i]) {
+			t.Errorf("request %d traceparent = %q, want a sampled version 00 header", i, headers[i])
+		}
+		if headers[i] != child.Context.TraceParent() {
+			t.Errorf("request %d traceparent = %q, want %q", i, headers[i], child.Context.TraceParent())
+		}
+	}
+}
+
+func TestClient_Tracer_RecordsError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	recorder := NewSpanRecorder()
+	client.Tracer = recorder
+
+	mux.HandleFunc("/v0.1/servers/test%2Fserver/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not Found", "status": 404}`)
+	})
+
+	_, _, err := client.Servers.ListVersionsByName(context.Background(), "test/server")
+	if !IsNotFound(err) {
+		t.Fatalf("ListVersionsByName error = %v, want IsNotFound", err)
+	}
+
+	spans := recorder.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	for _, span := range spans {
+		if !IsNotFound(span.Err) {
+			t.Errorf("span %q error = %v, want IsNotFound", span.Name, span.Err)
+		}
+	}
+	if got := spans[0].Attributes["mcp.server.name"]; got != "test/server" {
+		t.Errorf("mcp.server.name = %v, want %q", got, "test/server")
+	}
+	if got := spans[1].Attributes["http.response.status_code"]; got != http.StatusNotFound {
+		t.Errorf("http.response.status_code = %v, want 404", got)
+	}
+}
+
+func TestClient_Tracer_IterPages(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	recorder := NewSpanRecorder()
+	client.Tracer = recorder
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers": [{"server": {"name": "a/one", "version": "1.0.0"}}], "metadata": {"nextCursor": "next"}}`)
+	})
+
+	// Stop after the first page; the iteration span ends with it
+	for _, err := range client.Servers.IterPages(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("IterPages returned error: %v", err)
+		}
+		break
+	}
+
+	spans := recorder.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	if spans[0].Name != "Servers.IterPages" || spans[0].EndTime.IsZero() {
+		t.Errorf("first span = %q ended %v, want an ended Servers.IterPages span", spans[0].Name, !spans[0].EndTime.IsZero())
+	}
+	if spans[1].Parent != spans[0].Context {
+		t.Errorf("page span parent = %+v, want %+v", spans[1].Parent, spans[0].Context)
+	}
+}
+
+func TestClient_Tracer_CallerSpan(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	recorder := NewSpanRecorder()
+	client.Tracer = recorder
+
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	ctx, caller := recorder.Start(context.Background(), "caller")
+	if _, _, err := client.Meta.Ping(ctx); err != nil {
+		t.Fatalf("Meta.Ping returned error: %v", err)
+	}
+	caller.End()
+
+	spans := recorder.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	if spans[1].Parent != spans[0].Context {
+		t.Errorf("request span parent = %+v, want the caller's span %+v", spans[1].Parent, spans[0].Context)
+	}
+}
+
+func TestClient_Tracer_Default(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var header string
+	mux.HandleFunc("/v0.1/ping", func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("traceparent")
+		fmt.Fprint(w, `{"pong": true}`)
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "v0.1/ping", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if header != "" {
+		t.Errorf("traceparent header = %q, want none without a Tracer", header)
+	}
+
+	client.Tracer = NewSpanRecorder()
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if header == "" {
+		t.Error("traceparent header not sent with a Tracer")
+	}
+	if req.Header.Get("traceparent") != "" {
+		t.Error("Do modified the caller's request")
+	}
+}
+
+func TestWithTracer(t *testing.T) {
+	recorder := NewSpanRecorder()
+	client, err := New(WithTracer(recorder))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if client.Tracer != recorder {
+		t.Errorf("Tracer = %v, want %v", client.Tracer, recorder)
+	}
+}
@@ -1,16 +1,22 @@
 package mcp
 
 import (
+	"log/slog"
 	"net/http"
 	"net/url"
 	"sync"
 	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // Client manages communication with the MCP Registry API.
+//
+// A Client is safe for concurrent use by multiple goroutines once configured;
+// requests issued concurrently are sent in parallel.
 type Client struct {
-	clientMu sync.Mutex   // protects the client during calls
-	client   *http.Client // HTTP client used to communicate with the API
+	client *http.Client // HTTP client used to communicate with the API
 
 	// Base URL for API requests.
 	// Defaults to https://registry.modelcontextprotocol.io, but can be
@@ -20,14 +26,85 @@ type Client struct {
 	// User agent used when communicating with the MCP Registry API.
 	UserAgent string
 
+	// RetryPolicy controls how failed idempotent requests are retried.
+	// If nil, each request is attempted exactly once.
+	RetryPolicy *RetryPolicy
+
+	// WaitForRateLimit makes requests block until the rate limit window
+	// resets when the API last reported no remaining requests for the path,
+	// instead of sending a request that is bound to be rejected.
+	WaitForRateLimit bool
+
+	// RateLimiter optionally throttles outgoing requests client-side.
+	RateLimiter *TokenBucket
+
+	// VersionComparator orders versions of a server for
+	// GetByNameLatestActiveVersion and SortVersions. If nil, CompareVersions
+	// is used.
+	VersionComparator VersionComparator
+
+	// Cache optionally stores GET responses. Cached responses are served
+	// without contacting the API while fresh according to their
+	// Cache-Control or Expires headers, and revalidated with If-None-Match
+	// and If-Modified-Since once stale. If nil, responses are not cached.
+	Cache Cache
+
+	// CoalesceRequests makes identical GET requests issued concurrently
+	// share a single round-trip. Each caller still decodes its own copy of
+	// the response.
+	CoalesceRequests bool
+
+	// Logger receives a record of each request attempt, retry, rate limit
+	// wait, cache hit and page of servers listed. Authorization headers are
+	// redacted. If nil, nothing is logged.
+	Logger *slog.Logger
+
+	// Metrics optionally receives measurements of each request, such as its
+	// duration, status class and retries. See ExpvarMetrics for an
+	// implementation backed by the expvar package.
+	Metrics Metrics
+
+	// Tracer optionally starts a span for each request and for operations
+	// made of several requests, such as Servers.ListAll. If nil, no spans
+	// are started and no traceparent header is sent.
+	Tracer Tracer
+
+	// Endpoints optionally lists registry base URLs, in order of preference,
+	// that read requests fail over between. Requests are built against
+	// BaseURL, which is usually the first endpoint, and reads are sent to
+	// the first healthy endpoint instead. An endpoint is unhealthy for
+	// EndpointCooldown after a transport error or 5xx status. Writes are
+	// always sent to BaseURL.
+	Endpoints []*url.URL
+
+	// EndpointCooldown is how long a failed endpoint is skipped. If zero,
+	// DefaultEndpointCooldown is used.
+	EndpointCooldown time.Duration
+
+	middleware []Middleware // run around every request, outermost first
+
 	common service // Reuse a single struct instead of allocating one for each service
 
 	// Services used for talking to different parts of the MCP Registry API
 	Servers *ServersService
+	Auth    *AuthService
+	Meta    *MetaService
 
 	// Rate limit tracking
 	rateMu     sync.Mutex
 	rateLimits map[string]Rate
+
+	// Endpoints skipped until the given time after failing
+	endpointMu   sync.Mutex
+	endpointDown map[string]time.Time
+
+	// GET requests in flight, shared when CoalesceRequests is enabled
+	flights flightGroup
+
+	// Registry JWT sent with write requests
+	tokenMu        sync.Mutex
+	token          string
+	tokenExpiresAt time.Time // zero if the token does not expire
 }
 
 // service provides a general service interface for the API.
@@ -41,6 +118,18 @@ type service struct {
 // MCP Registry API docs: https://registry.modelcontextprotocol.io/docs
 type ServersService service
 
+// AuthService handles exchanging credentials for registry JWTs using the
+// authentication methods of the MCP Registry API.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs
+type AuthService service
+
+// MetaService handles communication with the health, ping and version
+// methods of the MCP Registry API.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs
+type MetaService service
+
 // Response wraps the standard http.Response and provides convenient access to
 // pagination and rate limit information.
 type Response struct {
@@ -51,6 +140,18 @@ type Response struct {
 
 	// Rate limiting information
 	Rate Rate
+
+	// FromCache reports whether the response was served from the Client's
+	// Cache, either without contacting the API or after the API confirmed
+	// with 304 Not Modified that the cached response is still valid. Rate is
+	// only set in the latter case.
+	FromCache bool
+
+	// Endpoint is the base URL of the registry that served the response,
+	// which differs from the Client's BaseURL when a read failed over to
+	// another of its Endpoints. It is nil for responses served from the
+	// Cache without contacting the API.
+	Endpoint *url.URL
 }
 
 // Rate represents the rate limit information returned in API responses.
@@ -65,6 +166,30 @@ type Rate struct {
 	Reset time.Time
 }
 
+// ServerPage is a single page of servers yielded by ServersService.IterPages.
+type ServerPage struct {
+	// Servers on this page, including registry metadata.
+	Servers []registryv0.ServerResponse
+
+	// Pagination metadata for this page.
+	Metadata registryv0.Metadata
+
+	// Response is the API response the page was decoded from.
+	Response *Response
+}
+
+// Resolution is the result of ServersService.Resolve.
+type Resolution struct {
+	// Match is the highest version satisfying the constraint, or nil if no
+	// version does.
+	Match *registryv0.ServerResponse
+
+	// Candidates lists every version that satisfied the constraint and the
+	// ResolveOptions filters, highest version first. Match is its first
+	// element.
+	Candidates []registryv0.ServerResponse
+}
+
 // ListOptions specifies the optional parameters to various List methods that
 // support pagination.
 type ListOptions struct {
@@ -100,3 +225,23 @@ type ServerGetOptions struct {
 	// If not specified, returns the latest version.
 	Version string `url:"version,omitempty"`
 }
+
+// ResolveOptions specifies the optional parameters to the
+// ServersService.Resolve method.
+type ResolveOptions struct {
+	// IncludeDeprecated considers deprecated versions as well as active ones.
+	// Deleted versions are never considered.
+	IncludeDeprecated bool
+
+	// IncludePrerelease considers prerelease versions such as 1.2.0-beta.1
+	// even if the constraint does not mention a prerelease.
+	IncludePrerelease bool
+}
+
+// ServerEditOptions specifies the optional parameters to the
+// ServersService.Edit method.
+type ServerEditOptions struct {
+	// Status changes the status of the version. If not specified, the
+	// status is left unchanged.
+	Status model.Status `url:"status,omitempty"`
+}
@@ -0,0 +1,538 @@
+// Package validate checks a server.json document against the MCP Registry's
+// schema and publishing rules without contacting the registry.
+//
+// Running the checks before ServersService.Publish lets CI pipelines catch
+// problems that the registry would otherwise reject:
+//
+//	if issues := validate.ServerJSON(&server); len(issues) > 0 {
+//		for _, issue := range issues {
+//			fmt.Println(issue)
+//		}
+//		os.Exit(1)
+//	}
+//
+// The checks mirror the registry's own validation. Checks that require
+// network access, such as verifying that an npm package declares the server
+// name, are left to the registry.
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Code identifies the kind of problem an Issue reports.
+type Code string
+
+// Issue codes reported by the validation functions.
+const (
+	CodeRequired                Code = "required"
+	CodeTooLong                 Code = "too_long"
+	CodeInvalidName             Code = "invalid_name"
+	CodeReservedVersion         Code = "reserved_version"
+	CodeVersionRange            Code = "version_range"
+	CodeInvalidURL              Code = "invalid_url"
+	CodeNamespaceMismatch       Code = "namespace_mismatch"
+	CodeUnsupportedSource       Code = "unsupported_source"
+	CodeInvalidSubfolder        Code = "invalid_subfolder"
+	CodeUnsupportedRegistryType Code = "unsupported_registry_type"
+	CodeRegistryBaseURLMismatch Code = "registry_base_url_mismatch"
+	CodeInvalidIdentifier       Code = "invalid_identifier"
+	CodeInvalidFileSHA256       Code = "invalid_file_sha256"
+	CodeUnsupportedTransport    Code = "unsupported_transport"
+	CodeUndefinedVariable       Code = "undefined_variable"
+	CodeInvalidArgument         Code = "invalid_argument"
+	CodeInvalidMeta             Code = "invalid_meta"
+	CodeMetaTooLarge            Code = "meta_too_large"
+)
+
+// Issue describes a single problem found in a server.json document.
+type Issue struct {
+	// Path is the JSON path of the offending field relative to the document
+	// root, such as "packages[0].transport.url".
+	Path string `json:"path"`
+
+	// Code identifies the kind of problem.
+	Code Code `json:"code"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// Issues is a list of problems found in a server.json document.
+type Issues []Issue
+
+// Err returns the issues as an error, or nil if there are none.
+func (is Issues) Err() error {
+	if len(is) == 0 {
+		return nil
+	}
+	return is
+}
+
+func (is Issues) Error() string {
+	msgs := make([]string, len(is))
+	for i, issue := range is {
+		msgs[i] = issue.String()
+	}
+	return fmt.Sprintf("server.json has %d issue(s): %s", len(is), strings.Join(msgs, "; "))
+}
+
+// Limits enforced by the registry schema.
+const (
+	maxNameLength        = 200
+	maxDescriptionLength = 100
+	maxMetaSize          = 4 * 1024
+)
+
+const publisherProvidedKey = "io.modelcontextprotocol.registry/publisher-provided"
+
+var (
+	namespacePattern = `[a-zA-Z0-9][a-zA-Z0-9.-]*[a-zA-Z0-9]`
+	namePartPattern  = `[a-zA-Z0-9][a-zA-Z0-9._-]*[a-zA-Z0-9]`
+
+	namespaceRegex = regexp.MustCompile(`^` + namespacePattern + `$`)
+	namePartRegex  = regexp.MustCompile(`^` + namePartPattern + `$`)
+
+	repositoryURLRegex = map[string]*regexp.Regexp{
+		"github": regexp.MustCompile(`^https?://(www\.)?github\.com/([\w.-]+)/[\w.-]+/?$`),
+		"gitlab": regexp.MustCompile(`^https?://(www\.)?gitlab\.com/([\w.-]+)/[\w.-]+/?$`),
+	}
+
+	subfolderRegex    = regexp.MustCompile(`^[a-zA-Z0-9\-_./]+$`)
+	sha256Regex       = regexp.MustCompile(`^[a-fA-F0-9]{64}$`)
+	templateVarRegex  = regexp.MustCompile(`\{([^}]+)\}`)
+	comparatorRangeRe = regexp.MustCompile(`^\s*(?:\^|~|>=|<=|>|<|=)\s*v?\d+(?:\.\d+){0,3}(?:-[0-9A-Za-z.-]+)?\s*$`)
+	hyphenRangeRe     = regexp.MustCompile(`^\s*v?\d+(?:\.\d+){0,3}(?:-[0-9A-Za-z.-]+)?\s-\s*v?\d+(?:\.\d+){0,3}(?:-[0-9A-Za-z.-]+)?\s*$`)
+	orRangeRe         = regexp.MustCompile(`^\s*(?:v?\d+(?:\.\d+){0,3}(?:-[0-9A-Za-z.-]+)?\s*)(?:\|\|\s*v?\d+(?:\.\d+){0,3}(?:-[0-9A-Za-z.-]+)?\s*)+$`)
+	wildcardVersionRe = regexp.MustCompile(`^\s*(?:v?\d+|x|X|\*)(?:\.(?:\d+|x|X|\*)){1,2}(?:-[0-9A-Za-z.-]+)?\s*$`)
+)
+
+// registryBaseURLs lists the base URLs the registry accepts for each package
+// registry type. The first entry is the default.
+var registryBaseURLs = map[string][]string{
+	model.RegistryTypeNPM:   {model.RegistryURLNPM},
+	model.RegistryTypePyPI:  {model.RegistryURLPyPI},
+	model.RegistryTypeNuGet: {model.RegistryURLNuGet},
+	model.RegistryTypeOCI:   {model.RegistryURLDocker, model.RegistryURLGHCR},
+	model.RegistryTypeMCPB:  {model.RegistryURLGitHub, model.RegistryURLGitLab},
+}
+
+// ServerJSON validates a server.json document and returns the issues found,
+// or nil if it is valid.
+func ServerJSON(server *registryv0.ServerJSON) Issues {
+	if server == nil {
+		return Issues{{Path: "", Code: CodeRequired, Message: "server.json is required"}}
+	}
+
+	issues := Name(server.Name)
+
+	// Consistency with the namespace is only checked for valid names.
+	var namespace string
+	if len(issues) == 0 {
+		namespace, _, _ = strings.Cut(server.Name, "/")
+	}
+
+	switch {
+	case server.Description == "":
+		issues = append(issues, Issue{"description", CodeRequired, "description is required"})
+	case len(server.Description) > maxDescriptionLength:
+		issues = append(issues, Issue{"description", CodeTooLong,
+			fmt.Sprintf("description must be at most %d characters, got %d", maxDescriptionLength, len(server.Description))})
+	}
+
+	issues = append(issues, checkVersion("version", server.Version)...)
+	issues = append(issues, checkRepository(&server.Repository, namespace)...)
+	issues = append(issues, checkWebsiteURL(server.WebsiteURL, namespace)...)
+
+	for i := range server.Packages {
+		issues = append(issues, checkPackage(fmt.Sprintf("packages[%d]", i), &server.Packages[i])...)
+	}
+	for i := range server.Remotes {
+		issues = append(issues, checkRemote(fmt.Sprintf("remotes[%d]", i), namespace, &server.Remotes[i])...)
+	}
+
+	issues = append(issues, checkMeta(server.Meta)...)
+
+	return issues
+}
+
+// Name validates a server name, which must be a reverse-DNS namespace and a
+// name separated by a single slash, such as "io.github.user/weather".
+func Name(name string) Issues {
+	const path = "name"
+
+	if name == "" {
+		return Issues{{path, CodeRequired, "name is required"}}
+	}
+	if len(name) > maxNameLength {
+		return Issues{{path, CodeTooLong, fmt.Sprintf("name must be at most %d characters, got %d", maxNameLength, len(name))}}
+	}
+
+	namespace, part, ok := strings.Cut(name, "/")
+	if !ok || namespace == "" || part == "" {
+		return Issues{{path, CodeInvalidName, "name must be in the format 'dns-namespace/name', such as 'com.example/server'"}}
+	}
+	if strings.Contains(part, "/") {
+		return Issues{{path, CodeInvalidName, "name must not contain more than one slash"}}
+	}
+	if !namespaceRegex.MatchString(namespace) {
+		return Issues{{path, CodeInvalidName, fmt.Sprintf("namespace %q must start and end with an alphanumeric character and may contain dots and hyphens", namespace)}}
+	}
+	if !namePartRegex.MatchString(part) {
+		return Issues{{path, CodeInvalidName, fmt.Sprintf("name %q must start and end with an alphanumeric character and may contain dots, underscores and hyphens", part)}}
+	}
+
+	return nil
+}
+
+// Version validates a version string, which must be a specific version:
+// neither the reserved "latest" nor a range such as "^1.0" or "1.x". Versions
+// need not be semantic versions, although the registry orders semantic
+// versions more reliably.
+func Version(version string) Issues {
+	return checkVersion("version", version)
+}
+
+func checkVersion(path, version string) Issues {
+	switch {
+	case version == "":
+		return Issues{{path, CodeRequired, "version is required"}}
+	case version == "latest":
+		return Issues{{path, CodeReservedVersion, "version 'latest' is reserved"}}
+	case looksLikeVersionRange(version):
+		return Issues{{path, CodeVersionRange, fmt.Sprintf("version %q must be a specific version, not a range", version)}}
+	}
+	return nil
+}
+
+// looksLikeVersionRange reports whether version uses range syntax, such as
+// "^1.2.3", ">=1.0", "1 - 2", "1.2 || 1.3" or "1.x".
+func looksLikeVersionRange(version string) bool {
+	v := strings.TrimSpace(version)
+	if v == "" {
+		return false
+	}
+	if comparatorRangeRe.MatchString(v) || hyphenRangeRe.MatchString(v) || orRangeRe.MatchString(v) {
+		return true
+	}
+	return wildcardVersionRe.MatchString(v) && strings.ContainsAny(v, "xX*")
+}
+
+func checkRepository(repo *model.Repository, namespace string) Issues {
+	if repo.URL == "" && repo.Source == "" {
+		return nil
+	}
+
+	re, ok := repositoryURLRegex[repo.Source]
+	if !ok {
+		return Issues{{"repository.source", CodeUnsupportedSource, fmt.Sprintf("repository source %q is not supported; use 'github' or 'gitlab'", repo.Source)}}
+	}
+
+	m := re.FindStringSubmatch(repo.URL)
+	if m == nil {
+		return Issues{{"repository.url", CodeInvalidURL, fmt.Sprintf("%q is not a valid %s repository URL", repo.URL, repo.Source)}}
+	}
+
+	var issues Issues
+	if !validSubfolder(repo.Subfolder) {
+		issues = append(issues, Issue{"repository.subfolder", CodeInvalidSubfolder,
+			fmt.Sprintf("subfolder %q must be a relative path without empty, '.' or '..' segments", repo.Subfolder)})
+	}
+
+	// Namespaces granted through GitHub or GitLab authentication name the
+	// account that owns the repository.
+	if owner, ok := strings.CutPrefix(namespace, "io."+repo.Source+"."); ok && !strings.EqualFold(owner, m[2]) {
+		issues = append(issues, Issue{"repository.url", CodeNamespaceMismatch,
+			fmt.Sprintf("repository owner %q does not match namespace %q", m[2], namespace)})
+	}
+
+	return issues
+}
+
+func validSubfolder(path string) bool {
+	if path == "" {
+		return true
+	}
+	if !subfolderRegex.MatchString(path) {
+		return false
+	}
+	for segment := range strings.SplitSeq(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func checkWebsiteURL(websiteURL, namespace string) Issues {
+	const path = "websiteUrl"
+
+	if websiteURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(websiteURL)
+	if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Issues{{path, CodeInvalidURL, fmt.Sprintf("websiteUrl %q must be an absolute http or https URL", websiteURL)}}
+	}
+
+	if msg := namespaceHostMismatch(namespace, u.Hostname()); msg != "" {
+		return Issues{{path, CodeNamespaceMismatch, msg}}
+	}
+	return nil
+}
+
+func checkRemote(path, namespace string, remote *model.Transport) Issues {
+	switch remote.Type {
+	case model.TransportTypeStreamableHTTP, model.TransportTypeSSE:
+	case "":
+		return Issues{{path + ".type", CodeRequired, "remote type is required"}}
+	default:
+		return Issues{{path + ".type", CodeUnsupportedTransport,
+			fmt.Sprintf("remote type %q is not supported; use %q or %q", remote.Type, model.TransportTypeStreamableHTTP, model.TransportTypeSSE)}}
+	}
+
+	urlPath := path + ".url"
+	if remote.URL == "" {
+		return Issues{{urlPath, CodeRequired, fmt.Sprintf("url is required for %s remotes", remote.Type)}}
+	}
+	if len(templateVarRegex.FindAllString(remote.URL, -1)) > 0 {
+		return Issues{{urlPath, CodeInvalidURL, fmt.Sprintf("remote url %q must not contain template variables", remote.URL)}}
+	}
+
+	u, ok := parseHTTPURL(remote.URL)
+	if !ok {
+		return Issues{{urlPath, CodeInvalidURL, fmt.Sprintf("remote url %q must be an absolute http or https URL", remote.URL)}}
+	}
+	if isLocalhost(u.Hostname()) {
+		return Issues{{urlPath, CodeInvalidURL, fmt.Sprintf("remote url %q must not point to localhost", remote.URL)}}
+	}
+	if msg := namespaceHostMismatch(namespace, u.Hostname()); msg != "" {
+		return Issues{{urlPath, CodeNamespaceMismatch, msg}}
+	}
+
+	return nil
+}
+
+func checkPackage(path string, pkg *model.Package) Issues {
+	var issues Issues
+
+	baseURLs, supported := registryBaseURLs[pkg.RegistryType]
+	switch {
+	case pkg.RegistryType == "":
+		issues = append(issues, Issue{path + ".registryType", CodeRequired, "registryType is required"})
+	case !supported:
+		issues = append(issues, Issue{path + ".registryType", CodeUnsupportedRegistryType,
+			fmt.Sprintf("registryType %q is not supported; use npm, pypi, oci, nuget or mcpb", pkg.RegistryType)})
+	case pkg.RegistryBaseURL != "" && !slices.Contains(baseURLs, pkg.RegistryBaseURL):
+		issues = append(issues, Issue{path + ".registryBaseUrl", CodeRegistryBaseURLMismatch,
+			fmt.Sprintf("registryBaseUrl %q is not valid for registryType %q; expected one of %s",
+				pkg.RegistryBaseURL, pkg.RegistryType, strings.Join(baseURLs, ", "))})
+	}
+
+	switch {
+	case pkg.Identifier == "":
+		issues = append(issues, Issue{path + ".identifier", CodeRequired, "identifier is required"})
+	case strings.Contains(pkg.Identifier, " "):
+		issues = append(issues, Issue{path + ".identifier", CodeInvalidIdentifier, "identifier must not contain spaces"})
+	case pkg.RegistryType == model.RegistryTypeMCPB:
+		issues = append(issues, checkMCPBIdentifier(path, pkg)...)
+	}
+
+	switch {
+	case pkg.FileSHA256 != "" && !sha256Regex.MatchString(pkg.FileSHA256):
+		issues = append(issues, Issue{path + ".fileSha256", CodeInvalidFileSHA256, "fileSha256 must be 64 hexadecimal characters"})
+	case pkg.FileSHA256 == "" && pkg.RegistryType == model.RegistryTypeMCPB:
+		issues = append(issues, Issue{path + ".fileSha256", CodeRequired, "fileSha256 is required for mcpb packages"})
+	}
+
+	issues = append(issues, checkVersion(path+".version", pkg.Version)...)
+
+	for i := range pkg.RuntimeArguments {
+		issues = append(issues, checkArgument(fmt.Sprintf("%s.runtimeArguments[%d]", path, i), &pkg.RuntimeArguments[i])...)
+	}
+	for i := range pkg.PackageArguments {
+		issues = append(issues, checkArgument(fmt.Sprintf("%s.packageArguments[%d]", path, i), &pkg.PackageArguments[i])...)
+	}
+
+	issues = append(issues, checkPackageTransport(path+".transport", pkg)...)
+
+	return issues
+}
+
+func checkMCPBIdentifier(path string, pkg *model.Package) Issues {
+	u, err := url.Parse(pkg.Identifier)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return Issues{{path + ".identifier", CodeInvalidIdentifier, fmt.Sprintf("mcpb identifier %q must be an https download URL", pkg.Identifier)}}
+	}
+
+	var inferred string
+	switch strings.ToLower(u.Host) {
+	case "github.com", "www.github.com":
+		inferred = model.RegistryURLGitHub
+	case "gitlab.com", "www.gitlab.com":
+		inferred = model.RegistryURLGitLab
+	default:
+		return Issues{{path + ".identifier", CodeInvalidIdentifier, fmt.Sprintf("mcpb identifier %q must be hosted on github.com or gitlab.com", pkg.Identifier)}}
+	}
+
+	if pkg.RegistryBaseURL != "" && pkg.RegistryBaseURL != inferred {
+		return Issues{{path + ".registryBaseUrl", CodeRegistryBaseURLMismatch,
+			fmt.Sprintf("registryBaseUrl %q does not match the identifier host; expected %s", pkg.RegistryBaseURL, inferred)}}
+	}
+	return nil
+}
+
+func checkArgument(path string, arg *model.Argument) Issues {
+	if arg.Type != model.ArgumentTypeNamed {
+		return nil
+	}
+
+	switch {
+	case arg.Name == "":
+		return Issues{{path + ".name", CodeRequired, "name is required for named arguments"}}
+	case strings.ContainsAny(arg.Name, "<> $"):
+		return Issues{{path + ".name", CodeInvalidArgument, fmt.Sprintf("named argument name %q must not contain spaces, '<', '>' or '$'", arg.Name)}}
+	case arg.Value != "" && strings.HasPrefix(arg.Value, arg.Name):
+		return Issues{{path + ".value", CodeInvalidArgument, fmt.Sprintf("value must not start with the argument name %q", arg.Name)}}
+	case arg.Default != "" && strings.HasPrefix(arg.Default, arg.Name):
+		return Issues{{path + ".default", CodeInvalidArgument, fmt.Sprintf("default must not start with the argument name %q", arg.Name)}}
+	}
+	return nil
+}
+
+func checkPackageTransport(path string, pkg *model.Package) Issues {
+	transport := pkg.Transport
+
+	switch transport.Type {
+	case model.TransportTypeStdio:
+		if transport.URL != "" {
+			return Issues{{path + ".url", CodeInvalidURL, "url must be empty for stdio transport"}}
+		}
+		return nil
+	case model.TransportTypeStreamableHTTP, model.TransportTypeSSE:
+	case "":
+		return Issues{{path + ".type", CodeRequired, "transport type is required"}}
+	default:
+		return Issues{{path + ".type", CodeUnsupportedTransport,
+			fmt.Sprintf("transport type %q is not supported; use %q, %q or %q",
+				transport.Type, model.TransportTypeStdio, model.TransportTypeStreamableHTTP, model.TransportTypeSSE)}}
+	}
+
+	urlPath := path + ".url"
+	if transport.URL == "" {
+		return Issues{{urlPath, CodeRequired, fmt.Sprintf("url is required for %s transport", transport.Type)}}
+	}
+
+	if _, ok := parseHTTPURL(fillTemplate(transport.URL)); !ok {
+		return Issues{{urlPath, CodeInvalidURL, fmt.Sprintf("url %q must be an absolute http or https URL", transport.URL)}}
+	}
+
+	available := packageVariables(pkg)
+	var issues Issues
+	for _, m := range templateVarRegex.FindAllStringSubmatch(transport.URL, -1) {
+		if !slices.Contains(available, m[1]) {
+			issues = append(issues, Issue{urlPath, CodeUndefinedVariable,
+				fmt.Sprintf("template variable {%s} is not defined by an argument or environment variable", m[1])})
+		}
+	}
+	return issues
+}
+
+// packageVariables returns the names that package transport URL templates
+// may reference: environment variable names and argument names and value
+// hints.
+func packageVariables(pkg *model.Package) []string {
+	var vars []string
+	for _, env := range pkg.EnvironmentVariables {
+		vars = append(vars, env.Name)
+	}
+	for _, args := range [][]model.Argument{pkg.RuntimeArguments, pkg.PackageArguments} {
+		for _, arg := range args {
+			if arg.Name != "" {
+				vars = append(vars, arg.Name)
+			}
+			if arg.ValueHint != "" {
+				vars = append(vars, arg.ValueHint)
+			}
+		}
+	}
+	return vars
+}
+
+func checkMeta(meta *registryv0.ServerMeta) Issues {
+	if meta == nil || meta.PublisherProvided == nil {
+		return nil
+	}
+
+	path := "_meta." + publisherProvidedKey
+	b, err := json.Marshal(meta.PublisherProvided)
+	if err != nil {
+		return Issues{{path, CodeInvalidMeta, fmt.Sprintf("cannot be encoded as JSON: %v", err)}}
+	}
+	if len(b) > maxMetaSize {
+		return Issues{{path, CodeMetaTooLarge, fmt.Sprintf("must be at most %d bytes, got %d", maxMetaSize, len(b))}}
+	}
+	return nil
+}
+
+// namespaceHostMismatch returns a message if host is neither the domain of
+// the reverse-DNS namespace nor one of its subdomains. Single-label and empty
+// namespaces are not checked.
+func namespaceHostMismatch(namespace, host string) string {
+	if isLocalhost(host) {
+		return ""
+	}
+
+	parts := strings.Split(namespace, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	slices.Reverse(parts)
+	domain := strings.Join(parts, ".")
+
+	if host == domain || strings.HasSuffix(host, "."+domain) {
+		return ""
+	}
+	return fmt.Sprintf("host %q does not match the namespace domain %q", host, domain)
+}
+
+// fillTemplate replaces the template variables in a URL with placeholder
+// values so that it can be parsed, using a numeric placeholder for ports.
+func fillTemplate(rawURL string) string {
+	return templateVarRegex.ReplaceAllStringFunc(rawURL, func(v string) string {
+		switch v {
+		case "{port}":
+			return "8080"
+		case "{host}":
+			return "example.com"
+		case "{scheme}", "{protocol}":
+			return "http"
+		}
+		return "placeholder"
+	})
+}
+
+func parseHTTPURL(raw string) (*url.URL, bool) {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+func isLocalhost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || strings.HasSuffix(host, ".localhost")
+}
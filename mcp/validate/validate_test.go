@@ -0,0 +1,336 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// loadFixture returns the valid server.json fixture in testdata.
+func loadFixture(t *testing.T) *registryv0.ServerJSON {
+	t.Helper()
+
+	b, err := os.ReadFile("testdata/server.json")
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+
+	var server registryv0.ServerJSON
+	if err := json.Unmarshal(b, &server); err != nil {
+		t.Fatalf("decoding fixture: %v", err)
+	}
+	return &server
+}
+
+func TestServerJSON_Valid(t *testing.T) {
+	if issues := ServerJSON(loadFixture(t)); issues != nil {
+		t.Errorf("ServerJSON(fixture) = %v, want no issues", issues)
+	}
+}
+
+func TestServerJSON_Issues(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(s *registryv0.ServerJSON)
+		want   []Issue
+	}{
+		{
+			name:   "missing name",
+			mutate: func(s *registryv0.ServerJSON) { s.Name = "" },
+			want:   []Issue{{Path: "name", Code: CodeRequired}},
+		},
+		{
+			name:   "name without namespace",
+			mutate: func(s *registryv0.ServerJSON) { s.Name = "weather" },
+			want:   []Issue{{Path: "name", Code: CodeInvalidName}},
+		},
+		{
+			name:   "name with multiple slashes",
+			mutate: func(s *registryv0.ServerJSON) { s.Name = "io.github.example/weather/extra" },
+			want:   []Issue{{Path: "name", Code: CodeInvalidName}},
+		},
+		{
+			name:   "namespace ending in dot",
+			mutate: func(s *registryv0.ServerJSON) { s.Name = "io.github.example./weather" },
+			want:   []Issue{{Path: "name", Code: CodeInvalidName}},
+		},
+		{
+			name:   "name too long",
+			mutate: func(s *registryv0.ServerJSON) { s.Name = "io.github.example/" + strings.Repeat("a", 200) },
+			want:   []Issue{{Path: "name", Code: CodeTooLong}},
+		},
+		{
+			name:   "missing description",
+			mutate: func(s *registryv0.ServerJSON) { s.Description = "" },
+			want:   []Issue{{Path: "description", Code: CodeRequired}},
+		},
+		{
+			name:   "description too long",
+			mutate: func(s *registryv0.ServerJSON) { s.Description = strings.Repeat("a", 101) },
+			want:   []Issue{{Path: "description", Code: CodeTooLong}},
+		},
+		{
+			name:   "missing version",
+			mutate: func(s *registryv0.ServerJSON) { s.Version = "" },
+			want:   []Issue{{Path: "version", Code: CodeRequired}},
+		},
+		{
+			name:   "reserved version",
+			mutate: func(s *registryv0.ServerJSON) { s.Version = "latest" },
+			want:   []Issue{{Path: "version", Code: CodeReservedVersion}},
+		},
+		{
+			name:   "caret range version",
+			mutate: func(s *registryv0.ServerJSON) { s.Version = "^1.0" },
+			want:   []Issue{{Path: "version", Code: CodeVersionRange}},
+		},
+		{
+			name:   "unsupported repository source",
+			mutate: func(s *registryv0.ServerJSON) { s.Repository.Source = "bitbucket" },
+			want:   []Issue{{Path: "repository.source", Code: CodeUnsupportedSource}},
+		},
+		{
+			name:   "invalid repository URL",
+			mutate: func(s *registryv0.ServerJSON) { s.Repository.URL = "https://github.com/example" },
+			want:   []Issue{{Path: "repository.url", Code: CodeInvalidURL}},
+		},
+		{
+			name:   "repository owner does not match namespace",
+			mutate: func(s *registryv0.ServerJSON) { s.Repository.URL = "https://github.com/someone-else/weather-mcp" },
+			want:   []Issue{{Path: "repository.url", Code: CodeNamespaceMismatch}},
+		},
+		{
+			name:   "subfolder with parent segment",
+			mutate: func(s *registryv0.ServerJSON) { s.Repository.Subfolder = "servers/../weather" },
+			want:   []Issue{{Path: "repository.subfolder", Code: CodeInvalidSubfolder}},
+		},
+		{
+			name:   "relative website URL",
+			mutate: func(s *registryv0.ServerJSON) { s.WebsiteURL = "docs/weather" },
+			want:   []Issue{{Path: "websiteUrl", Code: CodeInvalidURL}},
+		},
+		{
+			name:   "website URL outside namespace",
+			mutate: func(s *registryv0.ServerJSON) { s.WebsiteURL = "https://weather.example.com" },
+			want:   []Issue{{Path: "websiteUrl", Code: CodeNamespaceMismatch}},
+		},
+		{
+			name:   "unsupported package registry type",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[0].RegistryType = "cargo" },
+			want:   []Issue{{Path: "packages[0].registryType", Code: CodeUnsupportedRegistryType}},
+		},
+		{
+			name:   "mismatched registry base URL",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[0].RegistryBaseURL = model.RegistryURLPyPI },
+			want:   []Issue{{Path: "packages[0].registryBaseUrl", Code: CodeRegistryBaseURLMismatch}},
+		},
+		{
+			name:   "package identifier with spaces",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[0].Identifier = "weather mcp" },
+			want:   []Issue{{Path: "packages[0].identifier", Code: CodeInvalidIdentifier}},
+		},
+		{
+			name:   "package version range",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[0].Version = "1.x" },
+			want:   []Issue{{Path: "packages[0].version", Code: CodeVersionRange}},
+		},
+		{
+			name:   "mcpb without file hash",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[2].FileSHA256 = "" },
+			want:   []Issue{{Path: "packages[2].fileSha256", Code: CodeRequired}},
+		},
+		{
+			name:   "malformed file hash",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[2].FileSHA256 = "abc" },
+			want:   []Issue{{Path: "packages[2].fileSha256", Code: CodeInvalidFileSHA256}},
+		},
+		{
+			name: "mcpb hosted elsewhere",
+			mutate: func(s *registryv0.ServerJSON) {
+				s.Packages[2].Identifier = "https://example.com/weather.mcpb"
+			},
+			want: []Issue{{Path: "packages[2].identifier", Code: CodeInvalidIdentifier}},
+		},
+		{
+			name:   "named argument with embedded value",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[1].RuntimeArguments[0].Name = "--port 8080" },
+			want: []Issue{
+				{Path: "packages[1].runtimeArguments[0].name", Code: CodeInvalidArgument},
+			},
+		},
+		{
+			name:   "unsupported package transport",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[0].Transport.Type = "websocket" },
+			want:   []Issue{{Path: "packages[0].transport.type", Code: CodeUnsupportedTransport}},
+		},
+		{
+			name:   "stdio transport with URL",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[0].Transport.URL = "http://localhost:8080" },
+			want:   []Issue{{Path: "packages[0].transport.url", Code: CodeInvalidURL}},
+		},
+		{
+			name:   "undefined template variable",
+			mutate: func(s *registryv0.ServerJSON) { s.Packages[1].Transport.URL = "http://{host}:{port}/mcp" },
+			want:   []Issue{{Path: "packages[1].transport.url", Code: CodeUndefinedVariable}},
+		},
+		{
+			name:   "unsupported remote transport",
+			mutate: func(s *registryv0.ServerJSON) { s.Remotes[0].Type = model.TransportTypeStdio },
+			want:   []Issue{{Path: "remotes[0].type", Code: CodeUnsupportedTransport}},
+		},
+		{
+			name:   "remote on localhost",
+			mutate: func(s *registryv0.ServerJSON) { s.Remotes[0].URL = "http://localhost:8080/mcp" },
+			want:   []Issue{{Path: "remotes[0].url", Code: CodeInvalidURL}},
+		},
+		{
+			name:   "remote with template variables",
+			mutate: func(s *registryv0.ServerJSON) { s.Remotes[0].URL = "https://{host}/mcp" },
+			want:   []Issue{{Path: "remotes[0].url", Code: CodeInvalidURL}},
+		},
+		{
+			name:   "remote outside namespace",
+			mutate: func(s *registryv0.ServerJSON) { s.Remotes[0].URL = "https://weather.example.com/mcp" },
+			want:   []Issue{{Path: "remotes[0].url", Code: CodeNamespaceMismatch}},
+		},
+		{
+			name: "publisher metadata too large",
+			mutate: func(s *registryv0.ServerJSON) {
+				s.Meta.PublisherProvided["blob"] = strings.Repeat("a", 4096)
+			},
+			want: []Issue{{Path: "_meta.io.modelcontextprotocol.registry/publisher-provided", Code: CodeMetaTooLarge}},
+		},
+		{
+			name: "multiple issues",
+			mutate: func(s *registryv0.ServerJSON) {
+				s.Version = "latest"
+				s.Packages[0].Version = ">=1.0.0"
+				s.Remotes[0].URL = ""
+			},
+			want: []Issue{
+				{Path: "version", Code: CodeReservedVersion},
+				{Path: "packages[0].version", Code: CodeVersionRange},
+				{Path: "remotes[0].url", Code: CodeRequired},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := loadFixture(t)
+			tt.mutate(server)
+
+			issues := ServerJSON(server)
+
+			var got []Issue
+			for _, issue := range issues {
+				if issue.Message == "" {
+					t.Errorf("issue %s (%s) has no message", issue.Path, issue.Code)
+				}
+				got = append(got, Issue{Path: issue.Path, Code: issue.Code})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ServerJSON() = %v, want paths and codes %v", issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerJSON_Nil(t *testing.T) {
+	issues := ServerJSON(nil)
+	if len(issues) != 1 || issues[0].Code != CodeRequired {
+		t.Errorf("ServerJSON(nil) = %v, want a single required issue", issues)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    Code
+	}{
+		{"1.0.0", ""},
+		{"1.0.0-beta.1", ""},
+		{"2024.01.15", ""},
+		{"v1.2", ""},
+		{"1.2.3+build.5", ""},
+		{"", CodeRequired},
+		{"latest", CodeReservedVersion},
+		{"^1.2.3", CodeVersionRange},
+		{"~1.2", CodeVersionRange},
+		{">=1.0.0", CodeVersionRange},
+		{"<2", CodeVersionRange},
+		{"1.2.3 - 2.0.0", CodeVersionRange},
+		{"1.2 || 1.3", CodeVersionRange},
+		{"1.2.*", CodeVersionRange},
+		{"1.X", CodeVersionRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			issues := Version(tt.version)
+
+			var got Code
+			if len(issues) > 0 {
+				got = issues[0].Code
+			}
+			if got != tt.want {
+				t.Errorf("Version(%q) = %v, want code %q", tt.version, issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	valid := []string{
+		"io.github.example/weather",
+		"com.example/server_v2.beta",
+		"com.example.api/a1",
+	}
+	for _, name := range valid {
+		if issues := Name(name); issues != nil {
+			t.Errorf("Name(%q) = %v, want no issues", name, issues)
+		}
+	}
+
+	invalid := []string{
+		"/weather",
+		"com.example/",
+		"-com.example/weather",
+		"com.example/weather-",
+		"com.example/we ather",
+	}
+	for _, name := range invalid {
+		if issues := Name(name); len(issues) != 1 || issues[0].Code != CodeInvalidName {
+			t.Errorf("Name(%q) = %v, want an invalid name issue", name, issues)
+		}
+	}
+}
+
+func TestIssues_Err(t *testing.T) {
+	var none Issues
+	if err := none.Err(); err != nil {
+		t.Errorf("Issues(nil).Err() = %v, want nil", err)
+	}
+
+	issues := Issues{
+		{Path: "name", Code: CodeRequired, Message: "name is required"},
+		{Path: "version", Code: CodeReservedVersion, Message: "version 'latest' is reserved"},
+	}
+	err := issues.Err()
+
+	want := "server.json has 2 issue(s): name: name is required; version: version 'latest' is reserved"
+	if err == nil || err.Error() != want {
+		t.Errorf("Issues.Err() = %v, want %q", err, want)
+	}
+
+	var got Issues
+	if !errors.As(err, &got) || len(got) != 2 {
+		t.Errorf("errors.As(Issues.Err()) = %v, want the original issues", got)
+	}
+}
@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// VersionComparator orders two versions of the same server. It returns a
+// negative number if a is older than b, a positive number if a is newer, and
+// zero if neither is newer.
+type VersionComparator func(a, b registryv0.ServerResponse) int
+
+// CompareVersions is the default VersionComparator. It follows the ordering
+// the registry uses to determine the latest version:
+//
+//   - Two semantic versions (major.minor.patch, optionally prefixed with "v")
+//     are compared by semantic version precedence.
+//   - A semantic version is newer than any version that is not one.
+//   - Other versions, such as dates or commit hashes, are ordered by the time
+//     they were published.
+//
+// Remaining ties are broken by the registry's isLatest flag and then by the
+// version string, so that sorting is deterministic.
+func CompareVersions(a, b registryv0.ServerResponse) int {
+	va, aErr := parseStrictSemver(a.Server.Version)
+	vb, bErr := parseStrictSemver(b.Server.Version)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		if c := va.Compare(vb); c != 0 {
+			return c
+		}
+	case aErr == nil:
+		return 1
+	case bErr == nil:
+		return -1
+	default:
+		if c := publishedAt(a).Compare(publishedAt(b)); c != 0 {
+			return c
+		}
+	}
+
+	if c := compareBool(isLatest(a), isLatest(b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Server.Version, b.Server.Version)
+}
+
+// SortVersions sorts versions of a server from newest to oldest using the
+// client's VersionComparator, the same ordering GetByNameLatestActiveVersion
+// uses to choose the latest version.
+func (c *Client) SortVersions(versions []registryv0.ServerResponse) {
+	slices.SortStableFunc(versions, func(a, b registryv0.ServerResponse) int {
+		return c.compareVersions(b, a)
+	})
+}
+
+// compareVersions orders two versions using the client's VersionComparator,
+// or CompareVersions if none is set.
+func (c *Client) compareVersions(a, b registryv0.ServerResponse) int {
+	if c.VersionComparator != nil {
+		return c.VersionComparator(a, b)
+	}
+	return CompareVersions(a, b)
+}
+
+// parseStrictSemver parses a major.minor.patch version with an optional "v"
+// prefix, rejecting the abbreviated forms such as "1.2" that
+// semver.NewVersion accepts.
+func parseStrictSemver(version string) (*semver.Version, error) {
+	return semver.StrictNewVersion(strings.TrimPrefix(version, "v"))
+}
+
+func publishedAt(s registryv0.ServerResponse) time.Time {
+	if s.Meta.Official == nil {
+		return time.Time{}
+	}
+	return s.Meta.Official.PublishedAt
+}
+
+func isLatest(s registryv0.ServerResponse) bool {
+	return s.Meta.Official != nil && s.Meta.Official.IsLatest
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func serverVersion(version string, publishedAt time.Time, isLatest bool) registryv0.ServerResponse {
+	return registryv0.ServerResponse{
+		Server: registryv0.ServerJSON{Name: "com.example/server", Version: version},
+		Meta: registryv0.ResponseMeta{Official: &registryv0.RegistryExtensions{
+			Status:      model.StatusActive,
+			PublishedAt: publishedAt,
+			IsLatest:    isLatest,
+		}},
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		a, b registryv0.ServerResponse
+		want int
+	}{
+		{
+			name: "semantic versions by precedence",
+			a:    serverVersion("1.10.0", jan, false),
+			b:    serverVersion("1.9.0", feb, false),
+			want: 1,
+		},
+		{
+			name: "prerelease before release",
+			a:    serverVersion("2.0.0-rc.1", feb, false),
+			b:    serverVersion("2.0.0", jan, false),
+			want: -1,
+		},
+		{
+			name: "v prefix",
+			a:    serverVersion("v1.2.3", jan, false),
+			b:    serverVersion("1.2.4", jan, false),
+			want: -1,
+		},
+		{
+			name: "semantic version above other versions",
+			a:    serverVersion("0.0.1", jan, false),
+			b:    serverVersion("2024.02.01", feb, true),
+			want: 1,
+		},
+		{
+			name: "abbreviated versions are not semantic versions",
+			a:    serverVersion("1.2", feb, false),
+			b:    serverVersion("0.1.0", jan, false),
+			want: -1,
+		},
+		{
+			name: "other versions by publish time",
+			a:    serverVersion("a1b2c3d", feb, false),
+			b:    serverVersion("f00dfee", jan, false),
+			want: 1,
+		},
+		{
+			name: "isLatest breaks ties",
+			a:    serverVersion("a1b2c3d", jan, false),
+			b:    serverVersion("f00dfee", jan, true),
+			want: -1,
+		},
+		{
+			name: "version string breaks remaining ties",
+			a:    serverVersion("b", jan, false),
+			b:    serverVersion("a", jan, false),
+			want: 1,
+		},
+		{
+			name: "missing metadata",
+			a:    registryv0.ServerResponse{Server: registryv0.ServerJSON{Version: "nightly"}},
+			b:    serverVersion("weekly", jan, false),
+			want: -1,
+		},
+		{
+			name: "equal",
+			a:    serverVersion("1.0.0", jan, true),
+			b:    serverVersion("1.0.0", jan, true),
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareVersions(%s, %s) = %d, want %d", tt.a.Server.Version, tt.b.Server.Version, got, tt.want)
+			}
+			if got := CompareVersions(tt.b, tt.a); got != -tt.want {
+				t.Errorf("CompareVersions(%s, %s) = %d, want %d", tt.b.Server.Version, tt.a.Server.Version, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_SortVersions(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	versions := []registryv0.ServerResponse{
+		serverVersion("20240105", day(5), true),
+		serverVersion("1.0.0", day(1), false),
+		serverVersion("20240103", day(3), false),
+		serverVersion("1.1.0-beta.1", day(2), false),
+		serverVersion("1.1.0", day(4), false),
+	}
+
+	client := NewClient(nil)
+	client.SortVersions(versions)
+
+	var got []string
+	for _, v := range versions {
+		got = append(got, v.Server.Version)
+	}
+	want := []string{"1.1.0", "1.1.0-beta.1", "1.0.0", "20240105", "20240103"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortVersions() = %v, want %v", got, want)
+	}
+
+	// A custom comparator ordering purely by publish time.
+	client.VersionComparator = func(a, b registryv0.ServerResponse) int {
+		return a.Meta.Official.PublishedAt.Compare(b.Meta.Official.PublishedAt)
+	}
+	client.SortVersions(versions)
+
+	got = got[:0]
+	for _, v := range versions {
+		got = append(got, v.Server.Version)
+	}
+	want = []string{"20240105", "1.1.0", "20240103", "1.1.0-beta.1", "1.0.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortVersions() with custom comparator = %v, want %v", got, want)
+	}
+}
+
+func TestServersService_GetByNameLatestActiveVersion_NonSemver(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fdated/versions", func(w http.ResponseWriter, r *http.Request) {
+		versions := []struct{ version, publishedAt, status string }{
+			{"2024.01.15", "2024-01-15T00:00:00Z", "active"},
+			{"2024.03.01", "2024-03-01T00:00:00Z", "active"},
+			{"2024.04.01", "2024-04-01T00:00:00Z", "deprecated"},
+			{"2024.02.10", "2024-02-10T00:00:00Z", "active"},
+		}
+
+		var servers []string
+		for _, v := range versions {
+			servers = append(servers, fmt.Sprintf(`{
+				"server": {"name": "com.example/dated", "version": %q},
+				"_meta": {"io.modelcontextprotocol.registry/official": {"status": %q, "publishedAt": %q}}
+			}`, v.version, v.status, v.publishedAt))
+		}
+		fmt.Fprintf(w, `{"servers": [%s], "metadata": {}}`, strings.Join(servers, ","))
+	})
+
+	server, _, err := client.Servers.GetByNameLatestActiveVersion(context.Background(), "com.example/dated")
+	if err != nil {
+		t.Fatalf("Servers.GetByNameLatestActiveVersion returned error: %v", err)
+	}
+	if server == nil || server.Version != "2024.03.01" {
+		t.Errorf("Servers.GetByNameLatestActiveVersion = %+v, want version 2024.03.01", server)
+	}
+}